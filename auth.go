@@ -0,0 +1,64 @@
+package main
+
+import (
+	"errors"
+	"os"
+
+	"github.com/cli/go-gh/v2/pkg/auth"
+)
+
+// Auth token sources in order of precedence
+const (
+	tokenSourceFlag        = "--token"
+	tokenSourceGHToken     = "GH_TOKEN"
+	tokenSourceGitHubToken = "GITHUB_TOKEN"
+	tokenSourceGhAuth      = "gh auth"
+)
+
+// errNoToken is returned when no token source has a token, naming the ones that work
+// without a gh login, such as in CI
+var errNoToken = errors.New("no authentication token found: run `gh auth login`, or set GH_TOKEN or GITHUB_TOKEN")
+
+// resolveToken picks the auth token following a fixed precedence: the --token flag,
+// then GH_TOKEN, then GITHUB_TOKEN, then the credentials stored by gh auth.
+// It returns the token and the name of its source, or empty strings when none is found.
+func resolveToken(flagToken string, getenv func(string) string, ghAuthToken func() string) (string, string) {
+	if flagToken != "" {
+		return flagToken, tokenSourceFlag
+	}
+	for _, key := range []string{tokenSourceGHToken, tokenSourceGitHubToken} {
+		if token := getenv(key); token != "" {
+			return token, key
+		}
+	}
+	if token := ghAuthToken(); token != "" {
+		return token, tokenSourceGhAuth
+	}
+	return "", ""
+}
+
+// ghAuthToken returns the token gh has stored for the default host
+func ghAuthToken() string {
+	host, _ := auth.DefaultHost()
+	token, _ := auth.TokenForHost(host)
+	return token
+}
+
+// resolveHostToken picks the auth token for host, or the default host when empty. Enterprise
+// hosts skip GH_TOKEN and GITHUB_TOKEN, which belong to github.com, and use the token gh
+// resolves for them instead, such as GH_ENTERPRISE_TOKEN or stored credentials.
+func resolveHostToken(host, flagToken string) (string, string) {
+	if host == "" {
+		return resolveToken(flagToken, os.Getenv, ghAuthToken)
+	}
+	if !auth.IsEnterprise(host) {
+		return resolveToken(flagToken, os.Getenv, func() string {
+			token, _ := auth.TokenForHost(host)
+			return token
+		})
+	}
+	if flagToken != "" {
+		return flagToken, tokenSourceFlag
+	}
+	return auth.TokenForHost(host)
+}
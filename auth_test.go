@@ -0,0 +1,67 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveToken(t *testing.T) {
+	tests := []struct {
+		name       string
+		flagToken  string
+		env        map[string]string
+		ghToken    string
+		wantToken  string
+		wantSource string
+	}{
+		{
+			name:       "flag wins over everything",
+			flagToken:  "flag-token",
+			env:        map[string]string{"GH_TOKEN": "gh-token", "GITHUB_TOKEN": "github-token"},
+			ghToken:    "stored-token",
+			wantToken:  "flag-token",
+			wantSource: tokenSourceFlag,
+		},
+		{
+			name:       "GH_TOKEN wins over GITHUB_TOKEN",
+			env:        map[string]string{"GH_TOKEN": "gh-token", "GITHUB_TOKEN": "github-token"},
+			ghToken:    "stored-token",
+			wantToken:  "gh-token",
+			wantSource: tokenSourceGHToken,
+		},
+		{
+			name:       "GITHUB_TOKEN wins over gh auth",
+			env:        map[string]string{"GITHUB_TOKEN": "github-token"},
+			ghToken:    "stored-token",
+			wantToken:  "github-token",
+			wantSource: tokenSourceGitHubToken,
+		},
+		{
+			name:       "GITHUB_TOKEN without gh auth",
+			env:        map[string]string{"GITHUB_TOKEN": "github-token"},
+			wantToken:  "github-token",
+			wantSource: tokenSourceGitHubToken,
+		},
+		{
+			name:       "gh auth as last resort",
+			ghToken:    "stored-token",
+			wantToken:  "stored-token",
+			wantSource: tokenSourceGhAuth,
+		},
+		{
+			name: "no token available",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getenv := func(key string) string { return tt.env[key] }
+			ghAuth := func() string { return tt.ghToken }
+
+			token, source := resolveToken(tt.flagToken, getenv, ghAuth)
+			assert.Equal(t, tt.wantToken, token)
+			assert.Equal(t, tt.wantSource, source)
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/v67/github"
+)
+
+// botSuffix ends the logins of GitHub Apps such as "dependabot[bot]"
+const botSuffix = "[bot]"
+
+// authorList collects the values of the repeatable --exclude-author flag
+type authorList []string
+
+func (a *authorList) String() string {
+	return strings.Join(*a, ",")
+}
+
+func (a *authorList) Set(value string) error {
+	for _, login := range strings.Split(value, ",") {
+		login = strings.TrimSpace(login)
+		if login == "" {
+			return fmt.Errorf("author must not be empty")
+		}
+		*a = append(*a, login)
+	}
+	return nil
+}
+
+// isBotLogin reports whether a login belongs to a GitHub App
+func isBotLogin(login string) bool {
+	return strings.HasSuffix(strings.ToLower(login), botSuffix)
+}
+
+// normalizeLogin lowercases a login and removes any bot suffix, so "Renovate" matches
+// "renovate[bot]"
+func normalizeLogin(login string) string {
+	login = strings.ToLower(login)
+	return strings.TrimSuffix(login, botSuffix)
+}
+
+// authorExcluder decides which PR authors --exclude-bots and --exclude-author hide
+type authorExcluder struct {
+	bots    bool
+	authors map[string]bool // Normalized logins
+}
+
+// newAuthorExcluder creates an excluder hiding bots when requested and the given logins
+func newAuthorExcluder(bots bool, authors []string) authorExcluder {
+	e := authorExcluder{bots: bots, authors: make(map[string]bool, len(authors))}
+	for _, login := range authors {
+		e.authors[normalizeLogin(login)] = true
+	}
+	return e
+}
+
+// excludes reports whether PRs by login are hidden. PRs with an unknown author are kept.
+func (e authorExcluder) excludes(login string) bool {
+	if login == "" {
+		return false
+	}
+	return (e.bots && isBotLogin(login)) || e.authors[normalizeLogin(login)]
+}
+
+// filter drops the PRs whose authors are excluded
+func (e authorExcluder) filter(issues []*github.Issue) []*github.Issue {
+	var filtered []*github.Issue
+	for _, issue := range issues {
+		if !e.excludes(issue.GetUser().GetLogin()) {
+			filtered = append(filtered, issue)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-github/v67/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestPRByAuthor(title, login string) *github.Issue {
+	pr := createTestPRInRepo(title, "owner/repo")
+	pr.User = &github.User{Login: github.String(login)}
+	return pr
+}
+
+func TestIsBotLogin(t *testing.T) {
+	tests := []struct {
+		login string
+		want  bool
+	}{
+		{login: "dependabot[bot]", want: true},
+		{login: "renovate[bot]", want: true},
+		{login: "Copilot[BOT]", want: true},
+		{login: "alice", want: false},
+		{login: "robot", want: false},
+		{login: "bot", want: false},
+		{login: "", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.login, func(t *testing.T) {
+			assert.Equal(t, tt.want, isBotLogin(tt.login))
+		})
+	}
+}
+
+func TestAuthorExcluder(t *testing.T) {
+	tests := []struct {
+		name    string
+		bots    bool
+		authors []string
+		login   string
+		want    bool
+	}{
+		{name: "nothing excluded", login: "dependabot[bot]", want: false},
+		{name: "bot excluded", bots: true, login: "dependabot[bot]", want: true},
+		{name: "human kept with bots excluded", bots: true, login: "alice", want: false},
+		{name: "listed author", authors: []string{"alice"}, login: "alice", want: true},
+		{name: "listed author ignores case", authors: []string{"Alice"}, login: "ALICE", want: true},
+		{name: "bot listed without suffix", authors: []string{"renovate"}, login: "renovate[bot]", want: true},
+		{name: "bot listed with suffix", authors: []string{"renovate[bot]"}, login: "renovate[bot]", want: true},
+		{name: "unlisted author", authors: []string{"alice"}, login: "bob", want: false},
+		{name: "unknown author kept", bots: true, authors: []string{"alice"}, login: "", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, newAuthorExcluder(tt.bots, tt.authors).excludes(tt.login))
+		})
+	}
+}
+
+func TestAuthorListSet(t *testing.T) {
+	var authors authorList
+	assert.NoError(t, authors.Set("alice"))
+	assert.NoError(t, authors.Set("bob, carol"))
+	assert.Equal(t, authorList{"alice", "bob", "carol"}, authors)
+	assert.Equal(t, "alice,bob,carol", authors.String())
+	assert.Error(t, authors.Set(""))
+	assert.Error(t, authors.Set("dave,"))
+}
+
+func TestFilterIssuesExcludeAuthors(t *testing.T) {
+	issues := []*github.Issue{
+		createTestPRByAuthor("human", "alice"),
+		createTestPRByAuthor("dependabot", "dependabot[bot]"),
+		createTestPRByAuthor("renovate", "renovate[bot]"),
+		createTestPRByAuthor("colleague", "bob"),
+		createTestPR("unknown author", "url"),
+	}
+
+	tests := []struct {
+		name string
+		opts Options
+		want []string
+	}{
+		{
+			name: "no exclusions",
+			want: []string{"human", "dependabot", "renovate", "colleague", "unknown author"},
+		},
+		{
+			name: "bots",
+			opts: Options{ExcludeBots: true},
+			want: []string{"human", "colleague", "unknown author"},
+		},
+		{
+			name: "listed authors",
+			opts: Options{ExcludeAuthors: []string{"bob", "renovate"}},
+			want: []string{"human", "dependabot", "unknown author"},
+		},
+		{
+			name: "bots and listed authors",
+			opts: Options{ExcludeBots: true, ExcludeAuthors: []string{"alice"}},
+			want: []string{"colleague", "unknown author"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pc := &PRChecker{username: "testuser", opts: tt.opts}
+			got, err := pc.filterIssues(context.Background(), categoryReviewer, issues)
+			assert.NoError(t, err)
+			var titles []string
+			for _, issue := range got {
+				titles = append(titles, issue.GetTitle())
+			}
+			assert.Equal(t, tt.want, titles)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"hash/fnv"
+	"io"
+	"strings"
+	"unicode"
+
+	"github.com/fatih/color"
+	"github.com/google/go-github/v67/github"
+	"github.com/mattn/go-runewidth"
+)
+
+// compactAuthorLength is the width of the author column with --compact, as wide as its header
+const compactAuthorLength = len(authorLabel)
+
+// badgeColors is the palette logins are mapped onto for compact badges
+var badgeColors = []color.Attribute{
+	color.BgRed, color.BgGreen, color.BgYellow, color.BgBlue, color.BgMagenta, color.BgCyan,
+}
+
+// loginInitials returns up to two uppercase initials for a login: the first letter of the
+// first two words of a hyphenated, underscored or dotted login, or the first two letters
+// of a single word. A "[bot]" suffix is ignored and "?" is returned for an empty login.
+func loginInitials(login string) string {
+	login = strings.TrimSuffix(login, "[bot]")
+	words := strings.FieldsFunc(login, func(r rune) bool {
+		return r == '-' || r == '_' || r == '.'
+	})
+
+	var initials []rune
+	switch len(words) {
+	case 0:
+		return "?"
+	case 1:
+		initials = []rune(words[0])
+		if len(initials) > 2 {
+			initials = initials[:2]
+		}
+	default:
+		initials = []rune{[]rune(words[0])[0], []rune(words[1])[0]}
+	}
+	return strings.Map(unicode.ToUpper, string(initials))
+}
+
+// loginColor returns a badge color derived from the login, so the same user always gets
+// the same color regardless of letter case
+func loginColor(login string) *color.Color {
+	h := fnv.New32a()
+	h.Write([]byte(strings.ToLower(login)))
+	return color.New(badgeColors[h.Sum32()%uint32(len(badgeColors))], color.FgBlack)
+}
+
+// loginBadge renders a login's initials in its colored badge for the --compact author column
+func loginBadge(login string) string {
+	return loginColor(login).Sprint(plainBadge(login))
+}
+
+// plainBadge returns a login's badge text without its color
+func plainBadge(login string) string {
+	return " " + loginInitials(login) + " "
+}
+
+// writeAuthor writes the author cell of a row: the login, or its badge with --compact.
+// Badges of redacted authors are derived from their placeholders.
+func (pc *PRChecker) writeAuthor(w io.Writer, issue *github.Issue, width int) {
+	align := pc.alignment(columnAuthor)
+	if !pc.opts.Compact {
+		pc.formatter.authorStyle.Fprint(w, alignCell(pc.formatAuthor(issue), width, align))
+		return
+	}
+
+	var login string
+	if l := issue.GetUser().GetLogin(); l != "" {
+		login = pc.redact.user(l)
+	}
+	padding := strings.Repeat(" ", max(0, width-runewidth.StringWidth(plainBadge(login))))
+	if align == alignRight {
+		fmt.Fprint(w, padding+loginBadge(login))
+		return
+	}
+	fmt.Fprint(w, loginBadge(login)+padding)
+}
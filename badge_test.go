@@ -0,0 +1,85 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/google/go-github/v67/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoginInitials(t *testing.T) {
+	tests := []struct {
+		name  string
+		login string
+		want  string
+	}{
+		{name: "single word", login: "octocat", want: "OC"},
+		{name: "single letter", login: "k", want: "K"},
+		{name: "hyphenated", login: "koh-sh", want: "KS"},
+		{name: "several hyphens", login: "my-long-name", want: "ML"},
+		{name: "underscore and dot", login: "jane_doe.dev", want: "JD"},
+		{name: "leading hyphen", login: "-abc", want: "AB"},
+		{name: "bot account", login: "dependabot[bot]", want: "DE"},
+		{name: "non ascii", login: "ñandú", want: "ÑA"},
+		{name: "empty", login: "", want: "?"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, loginInitials(tt.login))
+		})
+	}
+}
+
+func TestLoginColorStable(t *testing.T) {
+	assert.Equal(t, loginColor("octocat"), loginColor("octocat"))
+	assert.Equal(t, loginColor("octocat"), loginColor("OctoCat"))
+}
+
+func TestWriteAuthor(t *testing.T) {
+	noColor := color.NoColor
+	defer func() { color.NoColor = noColor }()
+	color.NoColor = true
+
+	tests := []struct {
+		name  string
+		login string
+		opts  Options
+		want  string
+	}{
+		{name: "login", login: "octocat", want: "octocat   "},
+		{name: "compact badge", login: "koh-sh", opts: Options{Compact: true}, want: " KS       "},
+		{name: "compact unknown author", opts: Options{Compact: true}, want: " ?        "},
+		{name: "compact right aligned", login: "octocat", opts: Options{Compact: true, Align: map[string]string{columnAuthor: alignRight}}, want: "       OC "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issue := createTestPR("Test PR", "https://github.com/owner/repo/pull/1")
+			issue.User = nil
+			if tt.login != "" {
+				issue.User = &github.User{Login: github.String(tt.login)}
+			}
+			pc := &PRChecker{opts: tt.opts, formatter: NewDisplayFormatter()}
+
+			var out bytes.Buffer
+			pc.writeAuthor(&out, issue, 10)
+			assert.Equal(t, tt.want, out.String())
+		})
+	}
+}
+
+func TestCompactAuthorColumn(t *testing.T) {
+	pc := &PRChecker{width: 120, opts: Options{Compact: true}}
+	assert.Equal(t, compactAuthorLength, pc.layout(categoryReviewer).author)
+
+	noColor := color.NoColor
+	defer func() { color.NoColor = noColor }()
+	color.NoColor = false
+	var out bytes.Buffer
+	pc.formatter = NewDisplayFormatter()
+	pc.writeAuthor(&out, &github.Issue{User: &github.User{Login: github.String("octocat")}}, compactAuthorLength)
+	assert.Equal(t, loginBadge("octocat")+"  ", out.String())
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v67/github"
+)
+
+// resultCacheDirName is the directory in the user cache directory holding cached searches
+const resultCacheDirName = "results"
+
+// cachedResult is a search result stored by --cache along with when it was fetched
+type cachedResult struct {
+	FetchedAt time.Time                  `json:"fetched_at"`
+	Result    *github.IssuesSearchResult `json:"result"`
+}
+
+// resultCache stores search results by key
+type resultCache interface {
+	// load returns the entry stored under key, or nil when there is none
+	load(key string) (*cachedResult, error)
+	// store saves an entry under key, replacing any previous one
+	store(key string, entry *cachedResult) error
+}
+
+// fileCache stores each entry as a JSON file in dir, named after a hash of its key
+type fileCache struct {
+	dir string
+}
+
+// defaultResultCacheDir returns the location of cached searches in the user cache directory
+func defaultResultCacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "gh-myprs", resultCacheDirName), nil
+}
+
+// path returns the file holding the entry of key
+func (c *fileCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+func (c *fileCache) load(key string) (*cachedResult, error) {
+	path := c.path(key)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read result cache: %w", err)
+	}
+	var entry cachedResult
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, fmt.Errorf("failed to parse result cache in %s: %w", path, err)
+	}
+	return &entry, nil
+}
+
+// store writes the entry atomically so an interrupted run cannot corrupt it
+func (c *fileCache) store(key string, entry *cachedResult) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(c.dir, 0o700); err != nil {
+		return fmt.Errorf("failed to save result cache: %w", err)
+	}
+	path := c.path(key)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to save result cache: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to save result cache: %w", err)
+	}
+	return nil
+}
+
+// isFresh reports whether an entry was fetched no longer than ttl before now
+func (e *cachedResult) isFresh(now time.Time, ttl time.Duration) bool {
+	return e.Result != nil && now.Sub(e.FetchedAt) <= ttl
+}
+
+// resultCacheKey identifies a search by the user, host, request path and --limit, all
+// of which change its results
+func (pc *PRChecker) resultCacheKey(path string) string {
+	return strings.Join([]string{pc.username, pc.host, path, fmt.Sprintf("limit=%d", pc.opts.Limit)}, "\x00")
+}
+
+// cachesResults reports whether search results are stored with --cache
+func (pc *PRChecker) cachesResults() bool {
+	return pc.opts.Cache > 0
+}
+
+// resultStore returns the cache searches are stored in, or nil when the user cache
+// directory cannot be determined
+func (pc *PRChecker) resultStore() resultCache {
+	if pc.cache != nil {
+		return pc.cache
+	}
+	dir, err := defaultResultCacheDir()
+	if err != nil {
+		pc.debugf("not caching results: %v", err)
+		return nil
+	}
+	return &fileCache{dir: dir}
+}
+
+// cachedSearch returns the cached result of a search when it is younger than --cache.
+// --no-cache skips the lookup so the search is fetched again.
+func (pc *PRChecker) cachedSearch(key string) (*github.IssuesSearchResult, bool) {
+	if !pc.cachesResults() || pc.opts.NoCache {
+		return nil, false
+	}
+	store := pc.resultStore()
+	if store == nil {
+		return nil, false
+	}
+	entry, err := store.load(key)
+	if err != nil {
+		pc.debugf("ignoring result cache: %v", err)
+		return nil, false
+	}
+	if entry == nil || !entry.isFresh(pc.now(), pc.opts.Cache) {
+		return nil, false
+	}
+	return entry.Result, true
+}
+
+// storeSearch caches the result of a search. Failing to save only costs the next run a
+// request, so errors are reported as diagnostics.
+func (pc *PRChecker) storeSearch(key string, result *github.IssuesSearchResult) {
+	if !pc.cachesResults() {
+		return
+	}
+	store := pc.resultStore()
+	if store == nil {
+		return
+	}
+	if err := store.store(key, &cachedResult{FetchedAt: pc.now(), Result: result}); err != nil {
+		pc.debugf("%v", err)
+	}
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v67/github"
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryCache keeps cached results in memory
+type memoryCache struct {
+	entries map[string]*cachedResult
+}
+
+func newMemoryCache() *memoryCache {
+	return &memoryCache{entries: make(map[string]*cachedResult)}
+}
+
+func (c *memoryCache) load(key string) (*cachedResult, error) {
+	return c.entries[key], nil
+}
+
+func (c *memoryCache) store(key string, entry *cachedResult) error {
+	c.entries[key] = entry
+	return nil
+}
+
+func TestFileCache(t *testing.T) {
+	cache := &fileCache{dir: filepath.Join(t.TempDir(), "results")}
+
+	entry, err := cache.load("key")
+	assert.NoError(t, err)
+	assert.Nil(t, entry)
+
+	fetched := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	result := createTestPRList(createTestPR("Cached PR", "https://github.com/owner/repo/pull/1"))
+	assert.NoError(t, cache.store("key", &cachedResult{FetchedAt: fetched, Result: result}))
+
+	entry, err = cache.load("key")
+	assert.NoError(t, err)
+	assert.Equal(t, fetched, entry.FetchedAt)
+	assert.Equal(t, "Cached PR", entry.Result.Issues[0].GetTitle())
+
+	entry, err = cache.load("other key")
+	assert.NoError(t, err)
+	assert.Nil(t, entry)
+}
+
+func TestFetchPullRequestsCache(t *testing.T) {
+	now := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	cachedPR := createTestPRList(createTestPR("Cached PR", "https://github.com/owner/repo/pull/1"))
+
+	tests := []struct {
+		name         string
+		cachedAt     time.Time // Zero leaves the cache empty
+		noCache      bool
+		wantTitle    string
+		wantRequests int
+	}{
+		{name: "miss", wantTitle: "Fetched PR", wantRequests: 1},
+		{name: "hit", cachedAt: now.Add(-4 * time.Minute), wantTitle: "Cached PR", wantRequests: 0},
+		{name: "expired", cachedAt: now.Add(-6 * time.Minute), wantTitle: "Fetched PR", wantRequests: 1},
+		{name: "no-cache skips a fresh entry", cachedAt: now.Add(-time.Minute), noCache: true, wantTitle: "Fetched PR", wantRequests: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &MockGitHubClient{response: createTestPRList(createTestPR("Fetched PR", "https://github.com/owner/repo/pull/2"))}
+			cache := newMemoryCache()
+			pc := &PRChecker{
+				client:   client,
+				username: "testuser",
+				opts:     Options{Cache: 5 * time.Minute, NoCache: tt.noCache},
+				cache:    cache,
+				clock:    func() time.Time { return now },
+			}
+
+			query, err := pc.buildSearchQuery(categoryCreated)
+			assert.NoError(t, err)
+			key := pc.resultCacheKey("search/issues?q=" + query + pc.searchSortParams() + "&per_page=100")
+			if !tt.cachedAt.IsZero() {
+				assert.NoError(t, cache.store(key, &cachedResult{FetchedAt: tt.cachedAt, Result: cachedPR}))
+			}
+
+			result, _, err := pc.searchPullRequests(context.Background(), categoryCreated)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantTitle, result.Issues[0].GetTitle())
+			assert.Len(t, client.paths, tt.wantRequests)
+
+			// The cache holds the returned results, refreshed whenever they were fetched
+			entry, err := cache.load(key)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantTitle, entry.Result.Issues[0].GetTitle())
+			if tt.wantRequests > 0 {
+				assert.Equal(t, now, entry.FetchedAt)
+			}
+		})
+	}
+}
+
+func TestFetchPullRequestsWithoutCache(t *testing.T) {
+	client := &MockGitHubClient{response: createTestPRList(createTestPR("Fetched PR", "url"))}
+	cache := newMemoryCache()
+	pc := &PRChecker{client: client, username: "testuser", cache: cache}
+
+	_, _, err := pc.searchPullRequests(context.Background(), categoryCreated)
+	assert.NoError(t, err)
+	_, _, err = pc.searchPullRequests(context.Background(), categoryCreated)
+	assert.NoError(t, err)
+	assert.Len(t, client.paths, 2)
+	assert.Empty(t, cache.entries)
+}
+
+func TestResultCacheKey(t *testing.T) {
+	pc := &PRChecker{username: "testuser"}
+	key := pc.resultCacheKey("search/issues?q=a")
+
+	assert.Equal(t, key, pc.resultCacheKey("search/issues?q=a"))
+	assert.NotEqual(t, key, pc.resultCacheKey("search/issues?q=b"))
+	assert.NotEqual(t, key, (&PRChecker{username: "other"}).resultCacheKey("search/issues?q=a"))
+	assert.NotEqual(t, key, (&PRChecker{username: "testuser", host: "ghe.example.com"}).resultCacheKey("search/issues?q=a"))
+	assert.NotEqual(t, key, (&PRChecker{username: "testuser", opts: Options{Limit: 5}}).resultCacheKey("search/issues?q=a"))
+}
+
+func TestCachedResultIsFresh(t *testing.T) {
+	now := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	result := &github.IssuesSearchResult{}
+	assert.True(t, (&cachedResult{FetchedAt: now.Add(-5 * time.Minute), Result: result}).isFresh(now, 5*time.Minute))
+	assert.False(t, (&cachedResult{FetchedAt: now.Add(-5*time.Minute - time.Second), Result: result}).isFresh(now, 5*time.Minute))
+	assert.False(t, (&cachedResult{FetchedAt: now}).isFresh(now, 5*time.Minute))
+}
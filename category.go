@@ -0,0 +1,130 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// Category describes a section of PRs: the search qualifiers selecting them and the
+// icon and description shown in its header
+type Category struct {
+	Name        string
+	Icon        string
+	Description string // Header text preceding the username, e.g. "Review Requests for"
+	Summary     string // Describes the category's PRs in the summary line, e.g. "to review"; Name when empty
+	OptIn       bool   // Left out of the default sections, for categories that cost extra requests
+
+	// Qualifiers returns the "+"-separated search qualifiers scoping the category to
+	// username, added to the base open PR query
+	Qualifiers func(username string) string
+}
+
+// categoryRegistry holds the known categories in registration order
+var categoryRegistry struct {
+	mu         sync.RWMutex
+	categories []Category
+}
+
+func init() {
+	for _, c := range []Category{
+		{
+			Name:        categoryCreated,
+			Icon:        iconCreated,
+			Description: "Pull Requests Created by",
+			Summary:     "created",
+			Qualifiers:  func(username string) string { return "author:" + username },
+		},
+		{
+			Name:        categoryReviewer,
+			Icon:        iconReviewer,
+			Description: "Review Requests for",
+			Summary:     "to review",
+			Qualifiers:  func(username string) string { return "user-review-requested:" + username },
+		},
+		{
+			Name:        categoryAssigned,
+			Icon:        iconAssigned,
+			Description: "Pull Requests Assigned to",
+			Summary:     "assigned",
+			Qualifiers:  func(username string) string { return "assignee:" + username },
+		},
+		{
+			Name:        categoryMentioned,
+			Icon:        iconMentioned,
+			Description: "Pull Requests Mentioning",
+			Summary:     "mentioning you",
+			Qualifiers:  func(username string) string { return "mentions:" + username },
+		},
+		{
+			Name:        categoryReReview,
+			Icon:        iconReReview,
+			Description: "Pull Requests to Re-review for",
+			Summary:     "to re-review",
+			OptIn:       true,
+			// Submitting a review removes the reviewer from the requested ones, so previously
+			// reviewed PRs are searched instead and narrowed down by their commits
+			Qualifiers: func(username string) string { return "reviewed-by:" + username + "+-author:" + username },
+		},
+	} {
+		if err := RegisterCategory(c); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// RegisterCategory makes a category available to Run and --categories. Names must be
+// unique and a Qualifiers function is required.
+func RegisterCategory(c Category) error {
+	if c.Name == "" {
+		return fmt.Errorf("category name must not be empty")
+	}
+	if c.Qualifiers == nil {
+		return fmt.Errorf("category %s has no search qualifiers", c.Name)
+	}
+
+	categoryRegistry.mu.Lock()
+	defer categoryRegistry.mu.Unlock()
+	if slices.ContainsFunc(categoryRegistry.categories, func(existing Category) bool { return existing.Name == c.Name }) {
+		return fmt.Errorf("category %s is already registered", c.Name)
+	}
+	categoryRegistry.categories = append(categoryRegistry.categories, c)
+	return nil
+}
+
+// lookupCategory returns the registered category with the given name
+func lookupCategory(name string) (Category, error) {
+	categoryRegistry.mu.RLock()
+	defer categoryRegistry.mu.RUnlock()
+	for _, c := range categoryRegistry.categories {
+		if c.Name == name {
+			return c, nil
+		}
+	}
+	return Category{}, fmt.Errorf("unsupported PR category: %s", name)
+}
+
+// registeredCategories returns the names of all registered categories in registration order
+func registeredCategories() []string {
+	categoryRegistry.mu.RLock()
+	defer categoryRegistry.mu.RUnlock()
+	names := make([]string, 0, len(categoryRegistry.categories))
+	for _, c := range categoryRegistry.categories {
+		names = append(names, c.Name)
+	}
+	return names
+}
+
+// defaultCategories returns the names of the registered categories shown when none are
+// selected, leaving out opt-in ones
+func defaultCategories() []string {
+	categoryRegistry.mu.RLock()
+	defer categoryRegistry.mu.RUnlock()
+	var names []string
+	for _, c := range categoryRegistry.categories {
+		if !c.OptIn {
+			names = append(names, c.Name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// withCategory registers a category for the duration of a test
+func withCategory(t *testing.T, c Category) {
+	t.Helper()
+	categoryRegistry.mu.Lock()
+	saved := slices.Clone(categoryRegistry.categories)
+	categoryRegistry.mu.Unlock()
+	t.Cleanup(func() {
+		categoryRegistry.mu.Lock()
+		categoryRegistry.categories = saved
+		categoryRegistry.mu.Unlock()
+	})
+
+	assert.NoError(t, RegisterCategory(c))
+}
+
+func TestRegisterCategory(t *testing.T) {
+	withCategory(t, Category{
+		Name:        "team",
+		Icon:        "🏢",
+		Description: "Team Review Requests for",
+		Qualifiers:  func(username string) string { return "review-requested:" + username + "+repo:owner/name" },
+	})
+
+	pc := &PRChecker{username: "testuser"}
+	query, err := pc.buildSearchQuery("team")
+	assert.NoError(t, err)
+	assert.Equal(t, "is:open+is:pr+archived:false+review-requested:testuser+repo:owner/name", query)
+
+	header, err := pc.sectionHeader("team")
+	assert.NoError(t, err)
+	assert.Equal(t, "🏢 Team Review Requests for testuser", header)
+
+	assert.Equal(t, []string{categoryCreated, categoryReviewer, categoryAssigned, categoryMentioned, "team"}, uniqueCategories(nil))
+
+	opts, err := parseOptions([]string{"--categories", "team"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"team"}, opts.Categories)
+}
+
+func TestRegisterCategoryInvalid(t *testing.T) {
+	qualifiers := func(username string) string { return "author:" + username }
+
+	assert.Error(t, RegisterCategory(Category{Qualifiers: qualifiers}))
+	assert.Error(t, RegisterCategory(Category{Name: "no-qualifiers"}))
+	assert.ErrorContains(t, RegisterCategory(Category{Name: categoryCreated, Qualifiers: qualifiers}), "already registered")
+	assert.Equal(t, []string{categoryCreated, categoryReviewer, categoryAssigned, categoryMentioned, categoryReReview}, registeredCategories())
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-github/v67/github"
+)
+
+// checkStatus is the rolled-up state of a PR's latest checks
+type checkStatus string
+
+const (
+	checkStatusNone    checkStatus = ""        // No check runs or commit statuses reported
+	checkStatusPending checkStatus = "pending" // Some checks have not finished and none failed
+	checkStatusFailing checkStatus = "failing" // At least one check run or commit status failed
+	checkStatusPassing checkStatus = "passing" // Every check finished without failing
+)
+
+// Check status column shown with --checks
+const (
+	checksLabel     = "Checks" // Header of the check status column
+	maxChecksLength = 6        // Width of the check status column
+
+	iconChecksPassing = "✅"
+	iconChecksFailing = "❌"
+	iconChecksPending = "🟡"
+)
+
+// failedConclusions are the check run conclusions counted as failures
+var failedConclusions = map[string]bool{
+	"failure":         true,
+	"timed_out":       true,
+	"cancelled":       true,
+	"action_required": true,
+	"startup_failure": true,
+}
+
+// fetchCheckStatus returns the rolled-up status of the checks on a PR's head commit,
+// combining check runs with legacy commit statuses
+func (pc *PRChecker) fetchCheckStatus(ctx context.Context, repo string, pr *github.PullRequest) (checkStatus, error) {
+	sha := pr.GetHead().GetSHA()
+	if sha == "" {
+		return checkStatusNone, nil
+	}
+
+	var runs github.ListCheckRunsResults
+	path := fmt.Sprintf("repos/%s/commits/%s/check-runs?per_page=%d", repo, sha, enrichPerPage)
+	if err := pc.client.Get(ctx, path, &runs); err != nil {
+		return checkStatusNone, err
+	}
+	var status github.CombinedStatus
+	if err := pc.client.Get(ctx, fmt.Sprintf("repos/%s/commits/%s/status", repo, sha), &status); err != nil {
+		return checkStatusNone, err
+	}
+	return checksRollup(runs.CheckRuns, &status), nil
+}
+
+// checksRollup combines check runs and the combined commit status into a single state.
+// A failure anywhere makes the rollup failing, even while other checks are still running.
+func checksRollup(runs []*github.CheckRun, status *github.CombinedStatus) checkStatus {
+	var pending, reported bool
+	for _, run := range runs {
+		reported = true
+		if run.GetStatus() != "completed" {
+			pending = true
+			continue
+		}
+		if failedConclusions[run.GetConclusion()] {
+			return checkStatusFailing
+		}
+	}
+
+	// The combined state is "pending" when no statuses exist, so only trust it with statuses
+	if status != nil && status.GetTotalCount() > 0 {
+		reported = true
+		switch status.GetState() {
+		case "failure", "error":
+			return checkStatusFailing
+		case "pending":
+			pending = true
+		}
+	}
+
+	switch {
+	case pending:
+		return checkStatusPending
+	case reported:
+		return checkStatusPassing
+	default:
+		return checkStatusNone
+	}
+}
+
+// fetchesChecks reports whether the checks of each PR are fetched
+func (pc *PRChecker) fetchesChecks() bool {
+	return pc.opts.Checks || pc.opts.FailingChecks
+}
+
+// icon returns the icon shown for the status, or "-" when no checks were reported
+func (s checkStatus) icon() string {
+	switch s {
+	case checkStatusPassing:
+		return iconChecksPassing
+	case checkStatusFailing:
+		return iconChecksFailing
+	case checkStatusPending:
+		return iconChecksPending
+	default:
+		return "-"
+	}
+}
+
+// formatChecks returns the check status shown in the table for an issue
+func (pc *PRChecker) formatChecks(issue *github.Issue) string {
+	details := pc.detailsFor(issue)
+	if details == nil {
+		return "-"
+	}
+	return details.checks.icon()
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-github/v67/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestCheckRun(status, conclusion string) *github.CheckRun {
+	run := &github.CheckRun{Status: github.String(status)}
+	if conclusion != "" {
+		run.Conclusion = github.String(conclusion)
+	}
+	return run
+}
+
+func TestChecksRollup(t *testing.T) {
+	tests := []struct {
+		name   string
+		runs   []*github.CheckRun
+		status *github.CombinedStatus
+		want   checkStatus
+	}{
+		{
+			name: "no checks",
+			want: checkStatusNone,
+		},
+		{
+			name:   "empty combined status is not pending",
+			status: &github.CombinedStatus{State: github.String("pending"), TotalCount: github.Int(0)},
+			want:   checkStatusNone,
+		},
+		{
+			name: "all runs passed",
+			runs: []*github.CheckRun{createTestCheckRun("completed", "success"), createTestCheckRun("completed", "skipped")},
+			want: checkStatusPassing,
+		},
+		{
+			name: "failed run",
+			runs: []*github.CheckRun{createTestCheckRun("completed", "success"), createTestCheckRun("completed", "failure")},
+			want: checkStatusFailing,
+		},
+		{
+			name: "timed out run",
+			runs: []*github.CheckRun{createTestCheckRun("completed", "timed_out")},
+			want: checkStatusFailing,
+		},
+		{
+			name: "run in progress",
+			runs: []*github.CheckRun{createTestCheckRun("completed", "success"), createTestCheckRun("in_progress", "")},
+			want: checkStatusPending,
+		},
+		{
+			name: "failure wins over pending",
+			runs: []*github.CheckRun{createTestCheckRun("queued", ""), createTestCheckRun("completed", "failure")},
+			want: checkStatusFailing,
+		},
+		{
+			name:   "failed commit status",
+			runs:   []*github.CheckRun{createTestCheckRun("completed", "success")},
+			status: &github.CombinedStatus{State: github.String("error"), TotalCount: github.Int(1)},
+			want:   checkStatusFailing,
+		},
+		{
+			name:   "pending commit status",
+			status: &github.CombinedStatus{State: github.String("pending"), TotalCount: github.Int(2)},
+			want:   checkStatusPending,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, checksRollup(tt.runs, tt.status))
+		})
+	}
+}
+
+func TestKeepFailingChecks(t *testing.T) {
+	failing := createTestPR("failing", "url1")
+	pending := createTestPR("pending", "url2")
+	passing := createTestPR("passing", "url3")
+	unknown := createTestPR("unknown", "url4")
+	notEnriched := createTestPR("not enriched", "url5")
+
+	pc := &PRChecker{opts: Options{FailingChecks: true}}
+	pc.setDetails(failing, &prDetails{checks: checkStatusFailing})
+	pc.setDetails(pending, &prDetails{checks: checkStatusPending})
+	pc.setDetails(passing, &prDetails{checks: checkStatusPassing})
+	pc.setDetails(unknown, &prDetails{checks: checkStatusNone})
+
+	issues := []*github.Issue{failing, pending, passing, unknown, notEnriched}
+	for _, cat := range []string{categoryCreated, categoryReviewer} {
+		assert.True(t, pc.needsEnrichment(cat))
+		assert.Equal(t, []*github.Issue{failing}, pc.filterEnriched(cat, issues))
+	}
+}
+
+func TestFetchChecks(t *testing.T) {
+	tests := []struct {
+		name   string
+		run    *github.CheckRun
+		status string
+		want   checkStatus
+	}{
+		{
+			name:   "success",
+			run:    createTestCheckRun("completed", "success"),
+			status: "success",
+			want:   checkStatusPassing,
+		},
+		{
+			name:   "failure",
+			run:    createTestCheckRun("completed", "failure"),
+			status: "success",
+			want:   checkStatusFailing,
+		},
+		{
+			name:   "pending",
+			run:    createTestCheckRun("in_progress", ""),
+			status: "pending",
+			want:   checkStatusPending,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &MockGitHubClient{responses: map[string]interface{}{
+				"repos/owner/repo/commits/abc123/check-runs?per_page=100": &github.ListCheckRunsResults{
+					CheckRuns: []*github.CheckRun{tt.run},
+				},
+				"repos/owner/repo/commits/abc123/status": &github.CombinedStatus{State: github.String(tt.status), TotalCount: github.Int(1)},
+			}}
+			pc := &PRChecker{client: client}
+
+			pr := &github.PullRequest{Head: &github.PullRequestBranch{SHA: github.String("abc123")}}
+			state, err := pc.fetchCheckStatus(context.Background(), "owner/repo", pr)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, state)
+		})
+	}
+}
+
+func TestFormatChecks(t *testing.T) {
+	passing := createTestPR("passing", "url1")
+	failing := createTestPR("failing", "url2")
+	pending := createTestPR("pending", "url3")
+	unknown := createTestPR("unknown", "url4")
+	notEnriched := createTestPR("not enriched", "url5")
+
+	pc := &PRChecker{opts: Options{Checks: true}}
+	pc.setDetails(passing, &prDetails{checks: checkStatusPassing})
+	pc.setDetails(failing, &prDetails{checks: checkStatusFailing})
+	pc.setDetails(pending, &prDetails{checks: checkStatusPending})
+	pc.setDetails(unknown, &prDetails{checks: checkStatusNone})
+
+	assert.True(t, pc.fetchesChecks())
+	assert.True(t, pc.needsEnrichment(categoryReviewer))
+	assert.Equal(t, iconChecksPassing, pc.formatChecks(passing))
+	assert.Equal(t, iconChecksFailing, pc.formatChecks(failing))
+	assert.Equal(t, iconChecksPending, pc.formatChecks(pending))
+	assert.Equal(t, "-", pc.formatChecks(unknown))
+	assert.Equal(t, "-", pc.formatChecks(notEnriched))
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Color modes selectable with --color
+const (
+	colorAuto   = "auto"   // Colors only when stdout is a terminal and NO_COLOR is unset
+	colorAlways = "always" // Colors even when writing to a file or pipe
+	colorNever  = "never"  // Plain text, as with --no-color
+)
+
+// colorMode is a flag.Value for --color, which takes a mode as its value
+type colorMode string
+
+func (m *colorMode) String() string {
+	return string(*m)
+}
+
+func (m *colorMode) Set(value string) error {
+	switch value {
+	case colorAuto, colorAlways, colorNever:
+		*m = colorMode(value)
+		return nil
+	}
+	// --color=true and --color=false keep working as they did before the modes were added
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		return fmt.Errorf("must be auto, always or never")
+	}
+	*m = colorNever
+	if enabled {
+		*m = colorAlways
+	}
+	return nil
+}
+
+// useColor decides whether output is styled. Always and never win over everything else;
+// auto styles output only on a terminal, and never when --no-color, NO_COLOR or --output
+// turn colors off.
+func useColor(mode string, noColor, toFile, isTTY bool, getenv func(string) string) bool {
+	switch mode {
+	case colorAlways:
+		return true
+	case colorNever:
+		return false
+	default:
+		return isTTY && !colorDisabled(noColor || toFile, getenv)
+	}
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUseColor(t *testing.T) {
+	tests := []struct {
+		name    string
+		mode    string
+		noColor bool
+		toFile  bool
+		isTTY   bool
+		envSet  bool
+		want    bool
+	}{
+		{name: "auto on a terminal", mode: colorAuto, isTTY: true, want: true},
+		{name: "auto piped", mode: colorAuto},
+		{name: "auto writing a file", mode: colorAuto, isTTY: true, toFile: true},
+		{name: "auto with NO_COLOR", mode: colorAuto, isTTY: true, envSet: true},
+		{name: "auto with --no-color", mode: colorAuto, isTTY: true, noColor: true},
+		{name: "always piped", mode: colorAlways, want: true},
+		{name: "always writing a file with NO_COLOR", mode: colorAlways, toFile: true, envSet: true, want: true},
+		{name: "never on a terminal", mode: colorNever, isTTY: true},
+		{name: "unset mode behaves as auto", isTTY: true, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getenv := func(key string) string {
+				if tt.envSet && key == envNoColor {
+					return "1"
+				}
+				return ""
+			}
+			assert.Equal(t, tt.want, useColor(tt.mode, tt.noColor, tt.toFile, tt.isTTY, getenv))
+		})
+	}
+}
+
+func TestColorModeSet(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{value: "auto", want: colorAuto},
+		{value: "always", want: colorAlways},
+		{value: "never", want: colorNever},
+		{value: "true", want: colorAlways},
+		{value: "false", want: colorNever},
+		{value: "sometimes", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			var mode colorMode
+			err := mode.Set(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, mode.String())
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package main
+
+import (
+	"strconv"
+
+	"github.com/google/go-github/v67/github"
+)
+
+// Comment count column
+const (
+	commentsLabel     = "Comments" // Header of the comment count column
+	maxCommentsLength = 8          // Width of the comment count column
+)
+
+// formatComments returns the number of comments on a PR, which the search results
+// include, showing 0 when it is missing
+func formatComments(issue *github.Issue) string {
+	return strconv.Itoa(issue.GetComments())
+}
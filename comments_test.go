@@ -0,0 +1,33 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v67/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatComments(t *testing.T) {
+	discussed := createTestPR("Discussed", "url1")
+	discussed.Comments = github.Int(12)
+
+	assert.Equal(t, "12", formatComments(discussed))
+	assert.Equal(t, "0", formatComments(createTestPR("Quiet", "url2")))
+}
+
+func TestDisplayComments(t *testing.T) {
+	discussed := createTestPRInRepo("Discussed", "owner/repo")
+	discussed.Comments = github.Int(12)
+
+	var out bytes.Buffer
+	pc := &PRChecker{username: "testuser", formatter: NewDisplayFormatter(), out: &out, opts: Options{Comments: true}}
+	assert.NoError(t, pc.displayPullRequests([]*github.Issue{discussed}, categoryCreated))
+
+	// The count is right-aligned under its header
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	header, row := lines[len(lines)-3], lines[len(lines)-1]
+	headerEnd := strings.Index(header, commentsLabel) + len(commentsLabel)
+	assert.Equal(t, headerEnd, strings.Index(row, "12")+len("12"))
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// configFileName is the file in the user config directory holding flag defaults
+const configFileName = "config.yml"
+
+// Config holds defaults for command-line flags read from the config file. Unset fields
+// keep the built-in defaults, and flags given on the command line override both.
+type Config struct {
+	Format     string         `yaml:"format"`
+	Sort       string         `yaml:"sort"`
+	Limit      *int           `yaml:"limit"`
+	Categories []string       `yaml:"categories"`
+	NoColor    *bool          `yaml:"no-color"`
+	Timeout    *time.Duration `yaml:"timeout"`
+	Cache      *time.Duration `yaml:"cache"`
+}
+
+// defaultConfigPath returns the location of the config file in the user config directory
+func defaultConfigPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "gh-myprs", configFileName), nil
+}
+
+// loadConfig reads the config file at path, returning an empty Config when it does not
+// exist. Unknown keys are rejected so typos are not silently ignored.
+func loadConfig(path string) (Config, error) {
+	var cfg Config
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, err
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) { // EOF means an empty file
+		return Config{}, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// apply sets the flags the config file has values for, so they replace the built-in
+// defaults. Flags given on the command line, listed in skip, keep their values.
+func (c Config) apply(fs *flag.FlagSet, skip map[string]bool) error {
+	values := make(map[string]string)
+	if c.Format != "" {
+		values["format"] = c.Format
+	}
+	if c.Sort != "" {
+		values["sort"] = c.Sort
+	}
+	if c.Limit != nil {
+		values["limit"] = strconv.Itoa(*c.Limit)
+	}
+	if len(c.Categories) > 0 {
+		values["categories"] = strings.Join(c.Categories, ",")
+	}
+	if c.NoColor != nil {
+		values["no-color"] = strconv.FormatBool(*c.NoColor)
+	}
+	if c.Timeout != nil {
+		values["timeout"] = c.Timeout.String()
+	}
+	if c.Cache != nil {
+		values["cache"] = c.Cache.String()
+	}
+
+	for name, value := range values {
+		if skip[name] {
+			continue
+		}
+		if err := fs.Set(name, value); err != nil {
+			return fmt.Errorf("invalid %s in config file: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// loadDefaultConfig reads the config file from the user config directory. No config is
+// used when the directory cannot be determined, such as when HOME is unset.
+func loadDefaultConfig() (Config, error) {
+	path, err := defaultConfigPath()
+	if err != nil {
+		return Config{}, nil
+	}
+	return loadConfig(path)
+}
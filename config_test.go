@@ -0,0 +1,92 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeTestConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), configFileName)
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoadConfig(t *testing.T) {
+	path := writeTestConfig(t, `format: markdown
+sort: title
+limit: 5
+categories: [requested, created]
+no-color: true
+timeout: 30s
+cache: 5m
+`)
+	cfg, err := loadConfig(path)
+	assert.NoError(t, err)
+
+	limit, noColor, timeout, cache := 5, true, 30*time.Second, 5*time.Minute
+	assert.Equal(t, Config{
+		Format:     formatMarkdown,
+		Sort:       sortTitle,
+		Limit:      &limit,
+		Categories: []string{categoryReviewer, categoryCreated},
+		NoColor:    &noColor,
+		Timeout:    &timeout,
+		Cache:      &cache,
+	}, cfg)
+}
+
+func TestLoadConfigMissingOrEmpty(t *testing.T) {
+	cfg, err := loadConfig(filepath.Join(t.TempDir(), configFileName))
+	assert.NoError(t, err)
+	assert.Equal(t, Config{}, cfg)
+
+	cfg, err = loadConfig(writeTestConfig(t, ""))
+	assert.NoError(t, err)
+	assert.Equal(t, Config{}, cfg)
+}
+
+func TestLoadConfigInvalid(t *testing.T) {
+	_, err := loadConfig(writeTestConfig(t, "colour: false\n"))
+	assert.ErrorContains(t, err, "field colour not found")
+
+	_, err = loadConfig(writeTestConfig(t, "limit: many\n"))
+	assert.Error(t, err)
+}
+
+func TestConfigPrecedence(t *testing.T) {
+	cfg, err := loadConfig(writeTestConfig(t, "sort: title\nlimit: 5\nno-color: true\ntimeout: 30s\n"))
+	assert.NoError(t, err)
+
+	// The config file replaces the built-in defaults
+	opts, err := parseOptionsWithConfig(nil, cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, sortTitle, opts.Sort)
+	assert.Equal(t, 5, opts.Limit)
+	assert.True(t, opts.NoColor)
+	assert.Equal(t, 30*time.Second, opts.Timeout)
+	assert.Equal(t, timeFieldUpdated, opts.TimeField)
+
+	// Flags on the command line replace the config file
+	opts, err = parseOptionsWithConfig([]string{"--sort", "created", "--limit", "0", "--no-color=false"}, cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, sortCreated, opts.Sort)
+	assert.Equal(t, 0, opts.Limit)
+	assert.False(t, opts.NoColor)
+	assert.Equal(t, 30*time.Second, opts.Timeout)
+
+	// --color=always overrides no-color from the config file instead of conflicting with it
+	opts, err = parseOptionsWithConfig([]string{"--color=always"}, cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, colorAlways, opts.Color)
+
+	// Config values are validated like flags
+	_, err = parseOptionsWithConfig(nil, Config{Format: "xml"})
+	assert.ErrorContains(t, err, "xml")
+	_, err = parseOptionsWithConfig(nil, Config{Categories: []string{"merged"}})
+	assert.Error(t, err)
+}
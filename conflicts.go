@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/go-github/v67/github"
+)
+
+// Merge status of a PR against its base branch
+const (
+	mergeStatusUnknown     = ""            // GitHub has not finished computing mergeability
+	mergeStatusMergeable   = "mergeable"   // The PR merges without conflicts
+	mergeStatusConflicting = "conflicting" // The PR has merge conflicts
+)
+
+// Merge conflict marker shown with --conflicts
+const (
+	iconConflict = "🚧" // Shown before the titles of created PRs with merge conflicts
+
+	// mergeableStateDirty is the REST mergeable_state of PRs with merge conflicts
+	mergeableStateDirty = "dirty"
+)
+
+// Polling of mergeability, which GitHub computes in the background after a push
+const (
+	mergeStatusAttempts   = 3                      // Fetches of a PR while its mergeability is unknown
+	mergeStatusRetryDelay = 500 * time.Millisecond // Wait between fetches, unless PRChecker.mergeDelay is set
+)
+
+// fetchesMergeStatus reports whether --conflicts needs the merge status of a category's
+// PRs, which is only shown for the user's own PRs
+func (pc *PRChecker) fetchesMergeStatus(category string) bool {
+	return pc.opts.Conflicts && category == categoryCreated
+}
+
+// fetchMergeStatus retrieves whether an already fetched PR merges cleanly. GitHub reports
+// a null mergeable while it is still computing, so the PR is fetched again a few times
+// before giving up with mergeStatusUnknown.
+func (pc *PRChecker) fetchMergeStatus(ctx context.Context, repo string, number int, pr *github.PullRequest) (string, error) {
+	for attempt := 1; ; attempt++ {
+		if status := mergeStatus(pr); status != mergeStatusUnknown || attempt >= mergeStatusAttempts {
+			return status, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return mergeStatusUnknown, ctx.Err()
+		case <-time.After(pc.mergeRetryDelay()):
+		}
+
+		var err error
+		if pr, err = pc.fetchPull(ctx, repo, number); err != nil {
+			return mergeStatusUnknown, err
+		}
+	}
+}
+
+// mergeRetryDelay returns the wait between fetches of a PR whose mergeability is unknown
+func (pc *PRChecker) mergeRetryDelay() time.Duration {
+	if pc.mergeDelay > 0 {
+		return pc.mergeDelay
+	}
+	return mergeStatusRetryDelay
+}
+
+// mergeStatus returns the merge status reported by the pulls endpoint
+func mergeStatus(pr *github.PullRequest) string {
+	switch {
+	case pr.Mergeable == nil:
+		return mergeStatusUnknown
+	case pr.GetMergeableState() == mergeableStateDirty:
+		return mergeStatusConflicting
+	case pr.GetMergeable():
+		return mergeStatusMergeable
+	default:
+		return mergeStatusUnknown
+	}
+}
+
+// hasConflicts reports whether an enriched PR has merge conflicts
+func (pc *PRChecker) hasConflicts(issue *github.Issue) bool {
+	details := pc.detailsFor(issue)
+	return details != nil && details.mergeStatus == mergeStatusConflicting
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v67/github"
+	"github.com/stretchr/testify/assert"
+)
+
+// pullSequenceClient returns its PRs in turn for each request, repeating the last one
+type pullSequenceClient struct {
+	MockGitHubClient
+	pulls []*github.PullRequest
+	calls int
+}
+
+func (c *pullSequenceClient) Get(ctx context.Context, path string, response interface{}) error {
+	pr := c.pulls[min(c.calls, len(c.pulls)-1)]
+	c.calls++
+	b, err := json.Marshal(pr)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, response)
+}
+
+func createTestPull(mergeable *bool, state string) *github.PullRequest {
+	return &github.PullRequest{Mergeable: mergeable, MergeableState: github.String(state)}
+}
+
+func TestFetchMergeStatus(t *testing.T) {
+	tests := []struct {
+		name      string
+		pulls     []*github.PullRequest
+		want      string
+		wantCalls int
+	}{
+		{
+			name:      "mergeable",
+			pulls:     []*github.PullRequest{createTestPull(github.Bool(true), "clean")},
+			want:      mergeStatusMergeable,
+			wantCalls: 1,
+		},
+		{
+			name:      "conflicting",
+			pulls:     []*github.PullRequest{createTestPull(github.Bool(false), mergeableStateDirty)},
+			want:      mergeStatusConflicting,
+			wantCalls: 1,
+		},
+		{
+			name: "computed after a retry",
+			pulls: []*github.PullRequest{
+				createTestPull(nil, "unknown"),
+				createTestPull(github.Bool(false), mergeableStateDirty),
+			},
+			want:      mergeStatusConflicting,
+			wantCalls: 2,
+		},
+		{
+			name:      "still unknown after every attempt",
+			pulls:     []*github.PullRequest{createTestPull(nil, "unknown")},
+			want:      mergeStatusUnknown,
+			wantCalls: mergeStatusAttempts,
+		},
+		{
+			name:      "blocked but without conflicts",
+			pulls:     []*github.PullRequest{createTestPull(github.Bool(true), "blocked")},
+			want:      mergeStatusMergeable,
+			wantCalls: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &pullSequenceClient{pulls: tt.pulls}
+			pc := &PRChecker{client: client, mergeDelay: time.Millisecond}
+
+			pr, err := pc.fetchPull(context.Background(), "owner/repo", 1)
+			assert.NoError(t, err)
+			status, err := pc.fetchMergeStatus(context.Background(), "owner/repo", 1, pr)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, status)
+			assert.Equal(t, tt.wantCalls, client.calls)
+		})
+	}
+}
+
+func TestFetchMergeStatusCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	client := &pullSequenceClient{pulls: []*github.PullRequest{createTestPull(nil, "unknown")}}
+	pc := &PRChecker{client: client, mergeDelay: time.Hour}
+
+	pr, err := pc.fetchPull(ctx, "owner/repo", 1)
+	assert.NoError(t, err)
+	cancel()
+	status, err := pc.fetchMergeStatus(ctx, "owner/repo", 1, pr)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, mergeStatusUnknown, status)
+	assert.Equal(t, 1, client.calls)
+}
+
+func TestConflictMarker(t *testing.T) {
+	conflicting := createTestPR("conflicting", "url1")
+	mergeable := createTestPR("mergeable", "url2")
+	unknown := createTestPR("unknown", "url3")
+
+	pc := &PRChecker{opts: Options{Conflicts: true}}
+	assert.True(t, pc.fetchesMergeStatus(categoryCreated))
+	assert.True(t, pc.needsEnrichment(categoryCreated))
+	assert.False(t, pc.fetchesMergeStatus(categoryReviewer))
+
+	pc.setDetails(conflicting, &prDetails{mergeStatus: mergeStatusConflicting})
+	pc.setDetails(mergeable, &prDetails{mergeStatus: mergeStatusMergeable})
+	pc.setDetails(unknown, &prDetails{mergeStatus: mergeStatusUnknown})
+
+	assert.Equal(t, iconConflict+" conflicting", pc.formatTitle(conflicting, categoryCreated))
+	assert.Equal(t, "mergeable", pc.formatTitle(mergeable, categoryCreated))
+	assert.Equal(t, "unknown", pc.formatTitle(unknown, categoryCreated))
+}
+
+func TestGraphQLMergeStatus(t *testing.T) {
+	for mergeable, want := range map[string]string{
+		"MERGEABLE":   mergeStatusMergeable,
+		"CONFLICTING": mergeStatusConflicting,
+		"UNKNOWN":     mergeStatusUnknown,
+	} {
+		pr := &graphQLPullRequest{Mergeable: mergeable}
+		assert.Equal(t, want, pr.mergeStatus(), mergeable)
+	}
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/atotto/clipboard"
+)
+
+// clipboardWriter writes text to the system clipboard
+type clipboardWriter interface {
+	WriteAll(text string) error
+}
+
+// systemClipboard writes to the system clipboard through the platform's clipboard tool
+type systemClipboard struct{}
+
+func (systemClipboard) WriteAll(text string) error {
+	return clipboard.WriteAll(text)
+}
+
+// nopClipboard discards the text on platforms without clipboard support
+type nopClipboard struct{}
+
+func (nopClipboard) WriteAll(string) error {
+	return nil
+}
+
+// defaultClipboard returns the system clipboard, or a no-op clipboard when the platform
+// has no supported clipboard tool
+func defaultClipboard() clipboardWriter {
+	if clipboard.Unsupported {
+		return nopClipboard{}
+	}
+	return systemClipboard{}
+}
+
+// copyURLs writes the URLs to the clipboard one per line and confirms on out
+func copyURLs(cb clipboardWriter, urls []string, out io.Writer) error {
+	if err := cb.WriteAll(strings.Join(urls, "\n")); err != nil {
+		return fmt.Errorf("failed to copy URLs to the clipboard: %w", err)
+	}
+	if _, ok := cb.(nopClipboard); ok {
+		fmt.Fprintln(out, "warning: copying to the clipboard is not supported on this platform")
+		return nil
+	}
+	fmt.Fprintf(out, "Copied %d pull request URLs to the clipboard\n", len(urls))
+	return nil
+}
+
+// copyListed copies the listed PRs' URLs for --copy with pc.clipboard, or the system
+// clipboard when it is nil
+func (pc *PRChecker) copyListed(urls []string) error {
+	cb := pc.clipboard
+	if cb == nil {
+		cb = defaultClipboard()
+	}
+	return copyURLs(cb, urls, os.Stderr)
+}
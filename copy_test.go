@@ -0,0 +1,57 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// mockClipboard records the text written to it
+type mockClipboard struct {
+	text string
+	err  error
+}
+
+func (c *mockClipboard) WriteAll(text string) error {
+	c.text = text
+	return c.err
+}
+
+func TestCopyURLs(t *testing.T) {
+	cb := &mockClipboard{}
+	var out bytes.Buffer
+	assert.NoError(t, copyURLs(cb, []string{"url1", "url2"}, &out))
+	assert.Equal(t, "url1\nurl2", cb.text)
+	assert.Equal(t, "Copied 2 pull request URLs to the clipboard\n", out.String())
+
+	out.Reset()
+	assert.NoError(t, copyURLs(nopClipboard{}, []string{"url1"}, &out))
+	assert.Contains(t, out.String(), "not supported")
+
+	err := copyURLs(&mockClipboard{err: fmt.Errorf("xclip not found")}, []string{"url1"}, &out)
+	assert.ErrorContains(t, err, "xclip not found")
+}
+
+func TestRunCopy(t *testing.T) {
+	cb := &mockClipboard{}
+	now := time.Now()
+	response := createTestPRList(
+		createTestPRWithNumber(1, "https://github.com/owner/repo/pull/1", now.Add(-time.Hour)),
+		createTestPRWithNumber(2, "https://github.com/owner/repo/pull/2", now),
+	)
+	pc := &PRChecker{
+		client:    &MockGitHubClient{response: response},
+		username:  "testuser",
+		formatter: NewDisplayFormatter(),
+		clipboard: cb,
+		out:       &bytes.Buffer{},
+		opts:      Options{Copy: true, Limit: 1, Categories: []string{categoryCreated}},
+	}
+
+	// Only the PRs displayed within --limit are copied
+	assert.NoError(t, pc.Run())
+	assert.Equal(t, "https://github.com/owner/repo/pull/2", cb.text)
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+)
+
+// csvHeader names the columns of the CSV output
+var csvHeader = []string{"category", "number", "repo", "title", "author", "updated_at", "url"}
+
+// writeCSV writes a header row followed by one row per PR across every category, in
+// category order
+func writeCSV(w io.Writer, categories []string, records map[string][]prRecord) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, cat := range categories {
+		for _, record := range records[cat] {
+			row := []string{
+				cat,
+				strconv.Itoa(record.Number),
+				record.Repo,
+				record.Title,
+				record.Author,
+				record.UpdatedAt.Format(time.RFC3339),
+				record.URL,
+			}
+			if err := cw.Write(row); err != nil {
+				return err
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteCSV(t *testing.T) {
+	updated := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+	records := map[string][]prRecord{
+		categoryCreated: {{
+			Number:    12,
+			Title:     `Fix "quoted", comma-separated title`,
+			URL:       "https://github.com/owner/repo/pull/12",
+			Repo:      "owner/repo",
+			Author:    "testuser",
+			UpdatedAt: updated,
+		}},
+		categoryReviewer: {{
+			Number:    3,
+			Title:     "Review me",
+			URL:       "https://github.com/other/repo/pull/3",
+			Repo:      "other/repo",
+			Author:    "octocat",
+			UpdatedAt: updated.Add(-time.Hour),
+		}},
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, writeCSV(&buf, []string{categoryCreated, categoryReviewer, categoryAssigned}, records))
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		csvHeader,
+		{categoryCreated, "12", "owner/repo", `Fix "quoted", comma-separated title`, "testuser", "2024-01-02T15:04:05Z", "https://github.com/owner/repo/pull/12"},
+		{categoryReviewer, "3", "other/repo", "Review me", "octocat", "2024-01-02T14:04:05Z", "https://github.com/other/repo/pull/3"},
+	}, rows)
+}
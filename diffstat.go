@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/google/go-github/v67/github"
+	"github.com/mattn/go-runewidth"
+)
+
+// Diff size column shown in the created section
+const (
+	diffStatLabel     = "Diff" // Header of the diff size column
+	maxDiffStatLength = 13     // Width of the diff size column, enough for "+99999/-99999"
+)
+
+// diffStat is the size of a PR's changes
+type diffStat struct {
+	additions    int
+	deletions    int
+	changedFiles int
+}
+
+// fetchesDiffStat reports whether --diffstat needs the diff size of a category's PRs,
+// which is only shown for the user's own PRs
+func (pc *PRChecker) fetchesDiffStat(category string) bool {
+	return pc.opts.DiffStat && category == categoryCreated
+}
+
+// pullDiffStat returns the size of a PR's changes, which search results do not include
+func pullDiffStat(pr *github.PullRequest) *diffStat {
+	return &diffStat{additions: pr.GetAdditions(), deletions: pr.GetDeletions(), changedFiles: pr.GetChangedFiles()}
+}
+
+// formatDiffStat returns a diff size as "+X/-Y", or "-" when it was not fetched
+func formatDiffStat(stat *diffStat) string {
+	if stat == nil {
+		return "-"
+	}
+	return fmt.Sprintf("+%d/-%d", stat.additions, stat.deletions)
+}
+
+// writeDiffStat writes a PR's diff size cell with additions in green and deletions in
+// red, padded to the column width. Sizes too wide for the column are truncated unstyled.
+func (pc *PRChecker) writeDiffStat(w io.Writer, issue *github.Issue) {
+	var stat *diffStat
+	if details := pc.detailsFor(issue); details != nil {
+		stat = details.diffStat
+	}
+	text := formatDiffStat(stat)
+	width := runewidth.StringWidth(text)
+	if stat == nil || width > maxDiffStatLength {
+		fmt.Fprint(w, alignCell(text, maxDiffStatLength, alignLeft))
+		return
+	}
+	fmt.Fprintf(w, "%s/%s%s",
+		color.GreenString("+%d", stat.additions),
+		color.RedString("-%d", stat.deletions),
+		strings.Repeat(" ", maxDiffStatLength-width))
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/google/go-github/v67/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatDiffStat(t *testing.T) {
+	tests := []struct {
+		name string
+		stat *diffStat
+		want string
+	}{
+		{name: "not fetched", want: "-"},
+		{name: "empty", stat: &diffStat{}, want: "+0/-0"},
+		{name: "changes", stat: &diffStat{additions: 120, deletions: 4, changedFiles: 3}, want: "+120/-4"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, formatDiffStat(tt.stat))
+		})
+	}
+}
+
+func TestPullDiffStat(t *testing.T) {
+	pr := &github.PullRequest{
+		Additions:    github.Int(42),
+		Deletions:    github.Int(7),
+		ChangedFiles: github.Int(3),
+	}
+	assert.Equal(t, &diffStat{additions: 42, deletions: 7, changedFiles: 3}, pullDiffStat(pr))
+}
+
+func TestFetchesDiffStat(t *testing.T) {
+	pc := &PRChecker{opts: Options{DiffStat: true}}
+	assert.True(t, pc.fetchesDiffStat(categoryCreated))
+	assert.True(t, pc.needsEnrichment(categoryCreated))
+	assert.False(t, pc.fetchesDiffStat(categoryReviewer))
+
+	pc = &PRChecker{}
+	assert.False(t, pc.fetchesDiffStat(categoryCreated))
+}
+
+func TestWriteDiffStat(t *testing.T) {
+	noColor := color.NoColor
+	color.NoColor = true
+	defer func() { color.NoColor = noColor }()
+
+	fetched := createTestPR("fetched", "url1")
+	notFetched := createTestPR("not fetched", "url2")
+	pc := &PRChecker{opts: Options{DiffStat: true}}
+	pc.setDetails(fetched, &prDetails{diffStat: &diffStat{additions: 12, deletions: 3}})
+
+	var buf bytes.Buffer
+	pc.writeDiffStat(&buf, fetched)
+	assert.Equal(t, "+12/-3       ", buf.String())
+
+	buf.Reset()
+	pc.writeDiffStat(&buf, notFetched)
+	assert.Equal(t, "-            ", buf.String())
+}
@@ -0,0 +1,321 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v67/github"
+)
+
+// Enrichment configuration
+const (
+	enrichConcurrency = 5   // Default maximum number of PRs enriched at once
+	enrichPerPage     = 100 // Page size for per-PR comment and review listings
+	maxListPages      = 30  // Pages fetched per comment or review listing at most
+)
+
+// Activity indicators
+const (
+	iconOthersActivity = "💬" // Latest comment or review on the PR is from someone else
+)
+
+// Review states reported by the pull request reviews API
+const (
+	reviewStateApproved         = "APPROVED"
+	reviewStateChangesRequested = "CHANGES_REQUESTED"
+	reviewStateDismissed        = "DISMISSED"
+	reviewStatePending          = "PENDING"
+)
+
+// prDetails holds per-PR data that search results do not include
+type prDetails struct {
+	lastActor   string                      // Login of whoever left the latest comment or review
+	reviews     []*github.PullRequestReview // Reviews in submission order
+	decision    ReviewDecision              // Aggregated decision of the reviews
+	checks      checkStatus                 // Rolled-up state of the head commit's checks
+	unresolved  int                         // Number of review threads not yet resolved
+	diffStat    *diffStat                   // Size of the changes, nil unless --diffstat needs it
+	mergeStatus string                      // Whether the PR merges cleanly, fetched for --conflicts
+	reReview    bool                        // The user's latest review predates the head commit
+}
+
+// needsEnrichment reports whether any enabled option requires per-PR details for the category
+func (pc *PRChecker) needsEnrichment(category string) bool {
+	return pc.fetchesReviews(category) || pc.fetchesChecks() || pc.fetchesThreads() ||
+		pc.fetchesDiffStat(category) || pc.fetchesMergeStatus(category)
+}
+
+// fetchesReviews reports whether any enabled option reads the reviews of the category's PRs
+func (pc *PRChecker) fetchesReviews(category string) bool {
+	switch category {
+	case categoryCreated:
+		return pc.opts.Activity || pc.opts.Sort == sortUrgency || pc.filtersByReviews() || pc.opts.Prompt || pc.opts.Reviews
+	case categoryReviewer:
+		return pc.opts.PendingOnly
+	case categoryReReview:
+		return true
+	default:
+		return false
+	}
+}
+
+// enrichTarget is a PR whose details are fetched, along with its repository and number
+type enrichTarget struct {
+	issue  *github.Issue
+	repo   string
+	number int
+}
+
+// enrichIssues fetches per-PR details for the issues in a category, enriching at most
+// --enrich-concurrency PRs at once. Results without a repository or that are not PRs are
+// skipped.
+func (pc *PRChecker) enrichIssues(ctx context.Context, category string, issues []*github.Issue) error {
+	if !pc.needsEnrichment(category) {
+		return nil
+	}
+
+	var targets []enrichTarget
+	for _, issue := range issues {
+		repo := repoFromIssue(issue)
+		if number, ok := issueToPRNumber(issue); ok && repo != "" {
+			targets = append(targets, enrichTarget{issue: issue, repo: repo, number: number})
+		}
+	}
+	pc.progress.add(len(targets))
+
+	return forEachConcurrently(ctx, targets, pc.enrichConcurrency(), func(ctx context.Context, t enrichTarget) error {
+		defer pc.progress.step()
+		details, err := pc.fetchDetails(ctx, category, t.repo, t.number)
+		if err != nil {
+			return fmt.Errorf("failed to fetch details for %s#%d: %w", t.repo, t.number, err)
+		}
+		pc.setDetails(t.issue, details)
+		return nil
+	})
+}
+
+// enrichConcurrency returns the maximum number of PRs enriched at once
+func (pc *PRChecker) enrichConcurrency() int {
+	if pc.opts.EnrichConcurrency > 0 {
+		return pc.opts.EnrichConcurrency
+	}
+	return enrichConcurrency
+}
+
+// fetchDetails retrieves the per-PR data required by the enabled options
+func (pc *PRChecker) fetchDetails(ctx context.Context, category, repo string, number int) (*prDetails, error) {
+	details := &prDetails{}
+
+	if pc.fetchesReviews(category) {
+		decision, reviews, err := pc.fetchReviewDecision(ctx, repo, number)
+		if err != nil {
+			return nil, err
+		}
+		details.decision = decision
+		details.reviews = reviews
+	}
+
+	if category == categoryCreated && pc.opts.Activity {
+		path := fmt.Sprintf("repos/%s/issues/%d/comments?per_page=%d", repo, number, enrichPerPage)
+		comments, err := listAll[*github.IssueComment](ctx, pc, path)
+		if err != nil {
+			return nil, err
+		}
+		details.lastActor = lastActor(comments, details.reviews)
+	}
+
+	var pr *github.PullRequest
+	if pc.fetchesPull(category) {
+		var err error
+		if pr, err = pc.fetchPull(ctx, repo, number); err != nil {
+			return nil, err
+		}
+	}
+
+	if pc.fetchesChecks() {
+		checks, err := pc.fetchCheckStatus(ctx, repo, pr)
+		if err != nil {
+			return nil, err
+		}
+		details.checks = checks
+	}
+
+	if pc.fetchesThreads() {
+		threads, err := pc.fetchReviewThreads(ctx, repo, number)
+		if err != nil {
+			return nil, err
+		}
+		details.unresolved = countUnresolved(threads)
+	}
+
+	if pc.fetchesDiffStat(category) {
+		details.diffStat = pullDiffStat(pr)
+	}
+
+	if category == categoryReReview {
+		committedAt, err := pc.fetchHeadCommitTime(ctx, repo, pr)
+		if err != nil {
+			return nil, err
+		}
+		details.reReview = needsReReview(details.reviews, committedAt, pc.username)
+	}
+
+	if pc.fetchesMergeStatus(category) {
+		status, err := pc.fetchMergeStatus(ctx, repo, number, pr)
+		if err != nil {
+			return nil, err
+		}
+		details.mergeStatus = status
+	}
+
+	return details, nil
+}
+
+// fetchesPull reports whether any enabled option reads the pull request itself, which is
+// fetched once and shared by the checks, diff stat, re-review and merge status
+func (pc *PRChecker) fetchesPull(category string) bool {
+	return pc.fetchesChecks() || pc.fetchesDiffStat(category) || pc.fetchesMergeStatus(category) || category == categoryReReview
+}
+
+// fetchPull retrieves a pull request, which carries the head commit, diff size and
+// mergeability that search results lack
+func (pc *PRChecker) fetchPull(ctx context.Context, repo string, number int) (*github.PullRequest, error) {
+	var pr github.PullRequest
+	if err := pc.client.Get(ctx, fmt.Sprintf("repos/%s/pulls/%d", repo, number), &pr); err != nil {
+		return nil, err
+	}
+	return &pr, nil
+}
+
+// listAll fetches every page of a per-PR listing, which the API returns oldest first, so
+// the latest activity is on the last page. Pages are followed until one comes back short.
+func listAll[T any](ctx context.Context, pc *PRChecker, path string) ([]T, error) {
+	var all []T
+	for page := 1; page <= maxListPages; page++ {
+		pagePath := path
+		if page > 1 {
+			pagePath += fmt.Sprintf("&page=%d", page)
+		}
+		var items []T
+		if err := pc.client.Get(ctx, pagePath, &items); err != nil {
+			return nil, err
+		}
+		all = append(all, items...)
+		if len(items) < enrichPerPage {
+			return all, nil
+		}
+	}
+	pc.debugf("%s stopped after %d pages with %d items", path, maxListPages, len(all))
+	return all, nil
+}
+
+// resetDetails drops the details of the previous collection pass, whose issues are no
+// longer shown
+func (pc *PRChecker) resetDetails() {
+	pc.detailsMu.Lock()
+	defer pc.detailsMu.Unlock()
+	pc.details = nil
+}
+
+// setDetails stores the details fetched for an issue
+func (pc *PRChecker) setDetails(issue *github.Issue, details *prDetails) {
+	pc.detailsMu.Lock()
+	defer pc.detailsMu.Unlock()
+	if pc.details == nil {
+		pc.details = make(map[*github.Issue]*prDetails)
+	}
+	pc.details[issue] = details
+}
+
+// detailsFor returns the details fetched for an issue, or nil when it was not enriched
+func (pc *PRChecker) detailsFor(issue *github.Issue) *prDetails {
+	pc.detailsMu.Lock()
+	defer pc.detailsMu.Unlock()
+	return pc.details[issue]
+}
+
+// lastActor returns the login of whoever left the most recent comment or review,
+// or an empty string when the PR has no activity
+func lastActor(comments []*github.IssueComment, reviews []*github.PullRequestReview) string {
+	var latest time.Time
+	var actor string
+
+	consider := func(user *github.User, t time.Time) {
+		if user != nil && !t.IsZero() && !t.Before(latest) {
+			latest, actor = t, user.GetLogin()
+		}
+	}
+	for _, comment := range comments {
+		consider(comment.User, comment.GetCreatedAt().Time)
+	}
+	for _, review := range reviews {
+		consider(review.User, review.GetSubmittedAt().Time)
+	}
+	return actor
+}
+
+// latestReviewStates returns each reviewer's most recent approving or blocking review state.
+// Comments and pending reviews do not change a reviewer's state; dismissals clear it.
+func latestReviewStates(reviews []*github.PullRequestReview) map[string]string {
+	states := make(map[string]string)
+	for _, review := range reviews {
+		login := review.GetUser().GetLogin()
+		if login == "" {
+			continue
+		}
+		switch state := review.GetState(); state {
+		case reviewStateApproved, reviewStateChangesRequested:
+			states[login] = state
+		case reviewStateDismissed:
+			delete(states, login)
+		}
+	}
+	return states
+}
+
+// reviewCounts tallies reviewers by their latest approving or blocking review state
+type reviewCounts struct {
+	approvals        int
+	changesRequested int
+}
+
+// countReviews returns how many reviewers currently approve or request changes
+func countReviews(reviews []*github.PullRequestReview) reviewCounts {
+	var counts reviewCounts
+	for _, state := range latestReviewStates(reviews) {
+		switch state {
+		case reviewStateApproved:
+			counts.approvals++
+		case reviewStateChangesRequested:
+			counts.changesRequested++
+		}
+	}
+	return counts
+}
+
+// hasChangesRequested reports whether any reviewer's latest review requests changes
+func hasChangesRequested(reviews []*github.PullRequestReview) bool {
+	for _, state := range latestReviewStates(reviews) {
+		if state == reviewStateChangesRequested {
+			return true
+		}
+	}
+	return false
+}
+
+// hasReviewed reports whether username has submitted any review, including comment-only reviews
+func hasReviewed(reviews []*github.PullRequestReview, username string) bool {
+	for _, review := range reviews {
+		if strings.EqualFold(review.GetUser().GetLogin(), username) && review.GetState() != reviewStatePending {
+			return true
+		}
+	}
+	return false
+}
+
+// updatedByOthers reports whether the latest activity on a PR came from someone other than username
+func updatedByOthers(actor, username string) bool {
+	return actor != "" && !strings.EqualFold(actor, username)
+}
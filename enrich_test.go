@@ -0,0 +1,315 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v67/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestComment(login string, createdAt time.Time) *github.IssueComment {
+	return &github.IssueComment{
+		User:      &github.User{Login: github.String(login)},
+		CreatedAt: &github.Timestamp{Time: createdAt},
+	}
+}
+
+func createTestReview(login, state string, submittedAt time.Time) *github.PullRequestReview {
+	return &github.PullRequestReview{
+		User:        &github.User{Login: github.String(login)},
+		State:       github.String(state),
+		SubmittedAt: &github.Timestamp{Time: submittedAt},
+	}
+}
+
+func TestLastActor(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		comments []*github.IssueComment
+		reviews  []*github.PullRequestReview
+		want     string
+	}{
+		{
+			name: "no activity",
+			want: "",
+		},
+		{
+			name: "latest comment wins",
+			comments: []*github.IssueComment{
+				createTestComment("testuser", base),
+				createTestComment("reviewer", base.Add(time.Hour)),
+			},
+			reviews: []*github.PullRequestReview{
+				createTestReview("other", "COMMENTED", base.Add(time.Minute)),
+			},
+			want: "reviewer",
+		},
+		{
+			name: "latest review wins",
+			comments: []*github.IssueComment{
+				createTestComment("testuser", base),
+			},
+			reviews: []*github.PullRequestReview{
+				createTestReview("reviewer", "APPROVED", base.Add(time.Hour)),
+			},
+			want: "reviewer",
+		},
+		{
+			name: "pending review without timestamp ignored",
+			comments: []*github.IssueComment{
+				createTestComment("testuser", base),
+			},
+			reviews: []*github.PullRequestReview{
+				{User: &github.User{Login: github.String("reviewer")}, State: github.String("PENDING")},
+			},
+			want: "testuser",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, lastActor(tt.comments, tt.reviews))
+		})
+	}
+}
+
+func TestUpdatedByOthers(t *testing.T) {
+	tests := []struct {
+		name  string
+		actor string
+		want  bool
+	}{
+		{name: "no activity", actor: "", want: false},
+		{name: "self", actor: "testuser", want: false},
+		{name: "self different case", actor: "TestUser", want: false},
+		{name: "someone else", actor: "reviewer", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, updatedByOthers(tt.actor, "testuser"))
+		})
+	}
+}
+
+func TestEnrichIssuesActivity(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	mine := createTestPRInRepo("mine", "owner/repo")
+	mine.Number = github.Int(1)
+	theirs := createTestPRInRepo("theirs", "owner/repo")
+	theirs.Number = github.Int(2)
+	theirs.HTMLURL = github.String("https://github.com/owner/repo/pull/2")
+
+	client := &MockGitHubClient{
+		responses: map[string]interface{}{
+			"repos/owner/repo/issues/1/comments?per_page=100": []*github.IssueComment{createTestComment("testuser", base)},
+			"repos/owner/repo/pulls/1/reviews?per_page=100":   []*github.PullRequestReview{},
+			"repos/owner/repo/issues/2/comments?per_page=100": []*github.IssueComment{createTestComment("testuser", base)},
+			"repos/owner/repo/pulls/2/reviews?per_page=100": []*github.PullRequestReview{
+				createTestReview("reviewer", "CHANGES_REQUESTED", base.Add(time.Hour)),
+			},
+		},
+	}
+	pc := &PRChecker{
+		client:   client,
+		username: "testuser",
+		opts:     Options{Activity: true},
+	}
+
+	err := pc.enrichIssues(context.Background(), categoryCreated, []*github.Issue{mine, theirs})
+	assert.NoError(t, err)
+	assert.Equal(t, "mine", pc.formatTitle(mine, categoryCreated))
+	assert.Equal(t, iconOthersActivity+" theirs", pc.formatTitle(theirs, categoryCreated))
+
+	// Other categories are not enriched
+	client.paths = nil
+	assert.NoError(t, pc.enrichIssues(context.Background(), categoryReviewer, []*github.Issue{mine}))
+	assert.Empty(t, client.requestedPaths())
+}
+
+func TestEnrichIssuesFollowsPages(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	pr := createTestPRInRepo("busy", "owner/repo")
+	pr.Number = github.Int(1)
+
+	firstComments := make([]*github.IssueComment, enrichPerPage)
+	for i := range firstComments {
+		firstComments[i] = createTestComment("testuser", base.Add(time.Duration(i)*time.Minute))
+	}
+	firstReviews := make([]*github.PullRequestReview, enrichPerPage)
+	for i := range firstReviews {
+		firstReviews[i] = createTestReview("reviewer", reviewStateApproved, base.Add(time.Duration(i)*time.Minute))
+	}
+
+	client := &MockGitHubClient{
+		responses: map[string]interface{}{
+			"repos/owner/repo/issues/1/comments?per_page=100":        firstComments,
+			"repos/owner/repo/issues/1/comments?per_page=100&page=2": []*github.IssueComment{},
+			"repos/owner/repo/pulls/1/reviews?per_page=100":          firstReviews,
+			"repos/owner/repo/pulls/1/reviews?per_page=100&page=2": []*github.PullRequestReview{
+				createTestReview("reviewer", reviewStateChangesRequested, base.Add(time.Hour*12)),
+			},
+		},
+	}
+	pc := &PRChecker{client: client, username: "testuser", opts: Options{Activity: true}}
+
+	assert.NoError(t, pc.enrichIssues(context.Background(), categoryCreated, []*github.Issue{pr}))
+	details := pc.detailsFor(pr)
+	assert.Len(t, details.reviews, enrichPerPage+1)
+	assert.Equal(t, "reviewer", details.lastActor)
+	assert.True(t, hasChangesRequested(details.reviews))
+	assert.ElementsMatch(t, []string{
+		"repos/owner/repo/pulls/1/reviews?per_page=100",
+		"repos/owner/repo/pulls/1/reviews?per_page=100&page=2",
+		"repos/owner/repo/issues/1/comments?per_page=100",
+		"repos/owner/repo/issues/1/comments?per_page=100&page=2",
+	}, client.requestedPaths())
+}
+
+func TestFetchesReviews(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     Options
+		category string
+		want     bool
+	}{
+		{name: "nothing enabled", category: categoryCreated, want: false},
+		{name: "activity", opts: Options{Activity: true}, category: categoryCreated, want: true},
+		{name: "reviews column", opts: Options{Reviews: true}, category: categoryCreated, want: true},
+		{name: "urgency sort", opts: Options{Sort: sortUrgency}, category: categoryCreated, want: true},
+		{name: "review filter", opts: Options{MinApprovals: 1}, category: categoryCreated, want: true},
+		{name: "prompt", opts: Options{Prompt: true}, category: categoryCreated, want: true},
+		{name: "prompt skips review requests", opts: Options{Prompt: true}, category: categoryReviewer, want: false},
+		{name: "pending only", opts: Options{PendingOnly: true}, category: categoryReviewer, want: true},
+		{name: "rereview", category: categoryReReview, want: true},
+		{name: "checks, threads, diff stats and conflicts", opts: Options{Checks: true, Unresolved: true, DiffStat: true, Conflicts: true}, category: categoryCreated, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pc := &PRChecker{opts: tt.opts}
+			assert.Equal(t, tt.want, pc.fetchesReviews(tt.category))
+		})
+	}
+}
+
+func TestEnrichIssuesSkipsUnusedReviews(t *testing.T) {
+	pr := createTestPRInRepo("Test PR", "owner/repo")
+	pr.Number = github.Int(1)
+	client := &MockGitHubClient{responses: map[string]interface{}{
+		"repos/owner/repo/pulls/1": &github.PullRequest{Additions: github.Int(3), Deletions: github.Int(1)},
+	}}
+	pc := &PRChecker{client: client, username: "testuser", opts: Options{DiffStat: true}}
+
+	assert.NoError(t, pc.enrichIssues(context.Background(), categoryCreated, []*github.Issue{pr}))
+	assert.Equal(t, []string{"repos/owner/repo/pulls/1"}, client.requestedPaths())
+	assert.Equal(t, &diffStat{additions: 3, deletions: 1}, pc.detailsFor(pr).diffStat)
+}
+
+func TestEnrichIssuesFetchesPullOnce(t *testing.T) {
+	pr := createTestPRInRepo("Test PR", "owner/repo")
+	pr.Number = github.Int(1)
+	client := &MockGitHubClient{responses: map[string]interface{}{
+		"repos/owner/repo/pulls/1": &github.PullRequest{
+			Head:           &github.PullRequestBranch{SHA: github.String("abc123")},
+			Additions:      github.Int(3),
+			Mergeable:      github.Bool(false),
+			MergeableState: github.String(mergeableStateDirty),
+		},
+		"repos/owner/repo/commits/abc123/check-runs?per_page=100": &github.ListCheckRunsResults{},
+		"repos/owner/repo/commits/abc123/status":                  &github.CombinedStatus{},
+	}}
+	pc := &PRChecker{client: client, username: "testuser", opts: Options{Checks: true, DiffStat: true, Conflicts: true}}
+
+	assert.NoError(t, pc.enrichIssues(context.Background(), categoryCreated, []*github.Issue{pr}))
+	assert.Equal(t, []string{
+		"repos/owner/repo/pulls/1",
+		"repos/owner/repo/commits/abc123/check-runs?per_page=100",
+		"repos/owner/repo/commits/abc123/status",
+	}, client.requestedPaths())
+	details := pc.detailsFor(pr)
+	assert.Equal(t, 3, details.diffStat.additions)
+	assert.Equal(t, mergeStatusConflicting, details.mergeStatus)
+}
+
+func TestEnrichIssuesError(t *testing.T) {
+	pc := &PRChecker{
+		client:   &MockGitHubClient{err: fmt.Errorf("api error")},
+		username: "testuser",
+		opts:     Options{Activity: true},
+	}
+	issue := createTestPRInRepo("PR", "owner/repo")
+	issue.Number = github.Int(1)
+
+	err := pc.enrichIssues(context.Background(), categoryCreated, []*github.Issue{issue})
+	assert.Error(t, err)
+}
+
+func TestLatestReviewStates(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		reviews     []*github.PullRequestReview
+		want        map[string]string
+		wantChanges bool
+	}{
+		{
+			name: "no reviews",
+			want: map[string]string{},
+		},
+		{
+			name: "comment does not override changes requested",
+			reviews: []*github.PullRequestReview{
+				createTestReview("alice", reviewStateChangesRequested, base),
+				createTestReview("alice", "COMMENTED", base.Add(time.Hour)),
+			},
+			want:        map[string]string{"alice": reviewStateChangesRequested},
+			wantChanges: true,
+		},
+		{
+			name: "later approval replaces changes requested",
+			reviews: []*github.PullRequestReview{
+				createTestReview("alice", reviewStateChangesRequested, base),
+				createTestReview("bob", reviewStateApproved, base.Add(time.Minute)),
+				createTestReview("alice", reviewStateApproved, base.Add(time.Hour)),
+			},
+			want: map[string]string{"alice": reviewStateApproved, "bob": reviewStateApproved},
+		},
+		{
+			name: "dismissal clears state",
+			reviews: []*github.PullRequestReview{
+				createTestReview("alice", reviewStateChangesRequested, base),
+				createTestReview("alice", reviewStateDismissed, base.Add(time.Hour)),
+			},
+			want: map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, latestReviewStates(tt.reviews))
+			assert.Equal(t, tt.wantChanges, hasChangesRequested(tt.reviews))
+		})
+	}
+}
+
+func TestCountReviews(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	reviews := []*github.PullRequestReview{
+		createTestReview("alice", reviewStateApproved, base),
+		createTestReview("bob", reviewStateChangesRequested, base),
+		createTestReview("carol", reviewStateApproved, base),
+		createTestReview("carol", "COMMENTED", base.Add(time.Hour)),
+		createTestReview("dave", reviewStateApproved, base),
+		createTestReview("dave", reviewStateDismissed, base.Add(time.Hour)),
+	}
+
+	assert.Equal(t, reviewCounts{approvals: 2, changesRequested: 1}, countReviews(reviews))
+	assert.Equal(t, reviewCounts{}, countReviews(nil))
+}
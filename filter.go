@@ -0,0 +1,222 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/v67/github"
+)
+
+// Owned repository lookup configuration
+const (
+	reposPerPage = 100 // Maximum page size for the repositories endpoint
+	maxRepoPages = 10  // Upper bound on pages fetched when listing repositories
+)
+
+// filterIssues applies client-side filters to the issues fetched for a category
+func (pc *PRChecker) filterIssues(ctx context.Context, category string, issues []*github.Issue) ([]*github.Issue, error) {
+	if category == categoryCreated && pc.opts.OwnRepos {
+		owned, err := pc.ownedRepos(ctx)
+		if err != nil {
+			return nil, err
+		}
+		issues = filterByRepos(issues, owned)
+	}
+	if category == categoryReviewer {
+		issues = pc.excludeSelfAuthored(issues)
+	}
+	if pc.opts.ExternalOnly {
+		issues = filterExternal(issues)
+	}
+	if pc.opts.ExcludeBots || len(pc.opts.ExcludeAuthors) > 0 {
+		issues = newAuthorExcluder(pc.opts.ExcludeBots, pc.opts.ExcludeAuthors).filter(issues)
+	}
+	if len(pc.opts.IncludeLabels) > 0 || len(pc.opts.ExcludeLabels) > 0 {
+		issues = filterByLabels(issues, pc.opts.IncludeLabels, pc.opts.ExcludeLabels)
+	}
+	return issues, nil
+}
+
+// associationUnknown stands in for the author association when a search result omits it
+const associationUnknown = "UNKNOWN"
+
+// memberAssociations are the associations of authors who belong to the repository's
+// owner, as opposed to outside contributors
+var memberAssociations = map[string]bool{
+	"OWNER":        true,
+	"MEMBER":       true,
+	"COLLABORATOR": true,
+}
+
+// authorAssociation returns the author's association with the repository, or
+// associationUnknown when it is missing
+func authorAssociation(issue *github.Issue) string {
+	if association := issue.GetAuthorAssociation(); association != "" {
+		return association
+	}
+	return associationUnknown
+}
+
+// isExternalAuthor reports whether an association identifies an outside contributor.
+// Unknown associations are not treated as external.
+func isExternalAuthor(association string) bool {
+	return association != associationUnknown && !memberAssociations[association]
+}
+
+// filterExternal keeps only the PRs authored by outside contributors
+func filterExternal(issues []*github.Issue) []*github.Issue {
+	var filtered []*github.Issue
+	for _, issue := range issues {
+		if isExternalAuthor(authorAssociation(issue)) {
+			filtered = append(filtered, issue)
+		}
+	}
+	return filtered
+}
+
+// filterEnriched applies client-side filters that depend on per-PR details, so it must
+// run after enrichIssues
+func (pc *PRChecker) filterEnriched(category string, issues []*github.Issue) []*github.Issue {
+	if category == categoryReviewer && pc.opts.PendingOnly {
+		issues = pc.keepPending(issues)
+	}
+	if category == categoryReReview {
+		issues = pc.keepReReview(issues)
+	}
+	if category == categoryCreated && pc.filtersByReviews() {
+		issues = pc.keepReviewThreshold(issues)
+	}
+	if pc.opts.FailingChecks {
+		issues = pc.keepFailingChecks(issues)
+	}
+	if pc.opts.HasUnresolved {
+		issues = pc.keepUnresolved(issues)
+	}
+	return issues
+}
+
+// keepFailingChecks keeps only the PRs whose latest checks are failing; pending, passing
+// and unknown checks are all dropped
+func (pc *PRChecker) keepFailingChecks(issues []*github.Issue) []*github.Issue {
+	var filtered []*github.Issue
+	for _, issue := range issues {
+		if details := pc.detailsFor(issue); details != nil && details.checks == checkStatusFailing {
+			filtered = append(filtered, issue)
+		}
+	}
+	return filtered
+}
+
+// filtersByReviews reports whether created PRs are filtered by their review counts
+func (pc *PRChecker) filtersByReviews() bool {
+	return pc.opts.MinApprovals > 0 || pc.opts.HasChangesRequested
+}
+
+// keepReviewThreshold keeps only the PRs whose reviews satisfy --min-approvals or
+// --has-changes-requested. PRs without reviews never match.
+func (pc *PRChecker) keepReviewThreshold(issues []*github.Issue) []*github.Issue {
+	var filtered []*github.Issue
+	for _, issue := range issues {
+		var counts reviewCounts
+		if details := pc.detailsFor(issue); details != nil {
+			counts = countReviews(details.reviews)
+		}
+		if matchesReviewThreshold(counts, pc.opts.MinApprovals, pc.opts.HasChangesRequested) {
+			filtered = append(filtered, issue)
+		}
+	}
+	return filtered
+}
+
+// matchesReviewThreshold reports whether review counts reach at least minApprovals approvals
+// or, when changesRequested is set, include a changes request. Either criterion is enough
+// when both are enabled; a minApprovals of zero disables the approval criterion.
+func matchesReviewThreshold(counts reviewCounts, minApprovals int, changesRequested bool) bool {
+	if minApprovals > 0 && counts.approvals >= minApprovals {
+		return true
+	}
+	return changesRequested && counts.changesRequested > 0
+}
+
+// keepPending keeps only the review requests the user has not reviewed yet
+func (pc *PRChecker) keepPending(issues []*github.Issue) []*github.Issue {
+	var filtered []*github.Issue
+	for _, issue := range issues {
+		if details := pc.detailsFor(issue); details != nil && hasReviewed(details.reviews, pc.username) {
+			continue
+		}
+		filtered = append(filtered, issue)
+	}
+	return filtered
+}
+
+// excludeSelfAuthored drops PRs authored by the user, which can surface in review
+// requests through team requests but should never ask the user to review their own work
+func (pc *PRChecker) excludeSelfAuthored(issues []*github.Issue) []*github.Issue {
+	var filtered []*github.Issue
+	for _, issue := range issues {
+		if strings.EqualFold(issue.GetUser().GetLogin(), pc.username) {
+			pc.debugf("excluding self-authored PR from review requests: %s", issue.GetHTMLURL())
+			continue
+		}
+		filtered = append(filtered, issue)
+	}
+	return filtered
+}
+
+// ownedRepos returns the repositories the user owns or administers. They are fetched on
+// first use and kept for the rest of the run; a failed fetch is tried again next time.
+func (pc *PRChecker) ownedRepos(ctx context.Context) (map[string]bool, error) {
+	pc.ownedMu.Lock()
+	defer pc.ownedMu.Unlock()
+	if pc.owned != nil {
+		return pc.owned, nil
+	}
+
+	var repos []*github.Repository
+	for page := 1; ; page++ {
+		var batch []*github.Repository
+		path := fmt.Sprintf("user/repos?per_page=%d&page=%d", reposPerPage, page)
+		if err := pc.client.Get(ctx, path, &batch); err != nil {
+			return nil, fmt.Errorf("failed to fetch repositories: %w", err)
+		}
+		repos = append(repos, batch...)
+		if len(batch) < reposPerPage {
+			break
+		}
+		if page == maxRepoPages {
+			pc.debugf("repository listing stopped after %d pages with %d repositories; --own-repos may miss PRs in the rest", maxRepoPages, len(repos))
+			break
+		}
+	}
+	pc.owned = ownedRepoSet(repos, pc.username)
+	return pc.owned, nil
+}
+
+// ownedRepoSet returns the full names of repositories owned by username or where
+// the user has admin permission, which covers organization repositories they administer
+func ownedRepoSet(repos []*github.Repository, username string) map[string]bool {
+	owned := make(map[string]bool)
+	for _, repo := range repos {
+		if repo == nil || repo.FullName == nil {
+			continue
+		}
+		isOwner := repo.Owner != nil && strings.EqualFold(repo.Owner.GetLogin(), username)
+		if isOwner || repo.Permissions["admin"] {
+			owned[strings.ToLower(*repo.FullName)] = true
+		}
+	}
+	return owned
+}
+
+// filterByRepos keeps only the issues that belong to one of the given repositories
+func filterByRepos(issues []*github.Issue, repos map[string]bool) []*github.Issue {
+	var filtered []*github.Issue
+	for _, issue := range issues {
+		if repos[strings.ToLower(repoFromIssue(issue))] {
+			filtered = append(filtered, issue)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,237 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v67/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestRepo(fullName, owner string, admin bool) *github.Repository {
+	return &github.Repository{
+		FullName:    github.String(fullName),
+		Owner:       &github.User{Login: github.String(owner)},
+		Permissions: map[string]bool{"admin": admin},
+	}
+}
+
+func createTestPRInRepo(title, repo string) *github.Issue {
+	pr := createTestPR(title, "https://github.com/"+repo+"/pull/1")
+	pr.RepositoryURL = github.String("https://api.github.com/repos/" + repo)
+	return pr
+}
+
+func TestOwnedRepoSet(t *testing.T) {
+	repos := []*github.Repository{
+		createTestRepo("testuser/own", "testuser", true),
+		createTestRepo("some-org/admin", "some-org", true),
+		createTestRepo("some-org/member", "some-org", false),
+		{Owner: &github.User{Login: github.String("testuser")}}, // missing full name
+	}
+
+	got := ownedRepoSet(repos, "TestUser")
+	assert.Equal(t, map[string]bool{"testuser/own": true, "some-org/admin": true}, got)
+}
+
+func TestFilterByRepos(t *testing.T) {
+	issues := []*github.Issue{
+		createTestPRInRepo("own", "testuser/own"),
+		createTestPRInRepo("admin", "Some-Org/Admin"),
+		createTestPRInRepo("other", "other/repo"),
+		createTestPR("no repo", "url"),
+	}
+	owned := map[string]bool{"testuser/own": true, "some-org/admin": true}
+
+	got := filterByRepos(issues, owned)
+	var titles []string
+	for _, issue := range got {
+		titles = append(titles, issue.GetTitle())
+	}
+	assert.Equal(t, []string{"own", "admin"}, titles)
+}
+
+func TestFilterIssuesOwnRepos(t *testing.T) {
+	client := &MockGitHubClient{
+		response: []*github.Repository{createTestRepo("testuser/own", "testuser", true)},
+	}
+	issues := []*github.Issue{
+		createTestPRInRepo("own", "testuser/own"),
+		createTestPRInRepo("other", "other/repo"),
+	}
+
+	tests := []struct {
+		name      string
+		category  string
+		ownRepos  bool
+		wantCount int
+	}{
+		{name: "filter disabled", category: categoryCreated, wantCount: 2},
+		{name: "created filtered", category: categoryCreated, ownRepos: true, wantCount: 1},
+		{name: "requested unaffected", category: categoryReviewer, ownRepos: true, wantCount: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pc := &PRChecker{
+				client:   client,
+				username: "testuser",
+				opts:     Options{OwnRepos: tt.ownRepos},
+			}
+			got, err := pc.filterIssues(context.Background(), tt.category, issues)
+			assert.NoError(t, err)
+			assert.Len(t, got, tt.wantCount)
+		})
+	}
+}
+
+func TestOwnedReposRetriesAfterError(t *testing.T) {
+	client := &MockGitHubClient{err: fmt.Errorf("api error")}
+	pc := &PRChecker{client: client, username: "testuser"}
+
+	_, err := pc.ownedRepos(context.Background())
+	assert.Error(t, err)
+
+	// A later refresh fetches again instead of repeating the error
+	client.err = nil
+	client.response = []*github.Repository{createTestRepo("testuser/own", "testuser", true)}
+	owned, err := pc.ownedRepos(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, owned["testuser/own"])
+
+	// Successful results are kept
+	client.paths = nil
+	_, err = pc.ownedRepos(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, client.requestedPaths())
+}
+
+func TestExcludeSelfAuthored(t *testing.T) {
+	own := createTestPR("own", "url1")
+	own.User = &github.User{Login: github.String("TestUser")}
+	other := createTestPR("other", "url2")
+	other.User = &github.User{Login: github.String("someone")}
+	noAuthor := createTestPR("no author", "url3")
+
+	pc := &PRChecker{username: "testuser"}
+	issues := []*github.Issue{own, other, noAuthor}
+
+	got, err := pc.filterIssues(context.Background(), categoryReviewer, issues)
+	assert.NoError(t, err)
+	assert.Equal(t, []*github.Issue{other, noAuthor}, got)
+
+	// Self-authored PRs are expected in the created category
+	got, err = pc.filterIssues(context.Background(), categoryCreated, issues)
+	assert.NoError(t, err)
+	assert.Equal(t, issues, got)
+}
+
+func TestKeepPending(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	untouched := createTestPR("untouched", "url1")
+	commented := createTestPR("commented", "url2")
+	othersOnly := createTestPR("others only", "url3")
+	draftReview := createTestPR("draft review", "url4")
+	notEnriched := createTestPR("not enriched", "url5")
+
+	pc := &PRChecker{username: "testuser", opts: Options{PendingOnly: true}}
+	pc.setDetails(untouched, &prDetails{})
+	pc.setDetails(commented, &prDetails{reviews: []*github.PullRequestReview{
+		createTestReview("TestUser", "COMMENTED", base),
+	}})
+	pc.setDetails(othersOnly, &prDetails{reviews: []*github.PullRequestReview{
+		createTestReview("someone", reviewStateApproved, base),
+	}})
+	pc.setDetails(draftReview, &prDetails{reviews: []*github.PullRequestReview{
+		{User: &github.User{Login: github.String("testuser")}, State: github.String(reviewStatePending)},
+	}})
+
+	issues := []*github.Issue{untouched, commented, othersOnly, draftReview, notEnriched}
+	got := pc.filterEnriched(categoryReviewer, issues)
+	assert.Equal(t, []*github.Issue{untouched, othersOnly, draftReview, notEnriched}, got)
+
+	// Other categories are unaffected
+	assert.Equal(t, issues, pc.filterEnriched(categoryCreated, issues))
+}
+
+func TestMatchesReviewThreshold(t *testing.T) {
+	tests := []struct {
+		name             string
+		counts           reviewCounts
+		minApprovals     int
+		changesRequested bool
+		want             bool
+	}{
+		{name: "no reviews with min approvals", minApprovals: 1, want: false},
+		{name: "no reviews with changes requested", changesRequested: true, want: false},
+		{name: "enough approvals", counts: reviewCounts{approvals: 2}, minApprovals: 2, want: true},
+		{name: "too few approvals", counts: reviewCounts{approvals: 1}, minApprovals: 2, want: false},
+		{name: "changes requested", counts: reviewCounts{changesRequested: 1}, changesRequested: true, want: true},
+		{name: "changes requested not selected", counts: reviewCounts{changesRequested: 1}, minApprovals: 1, want: false},
+		{name: "either criterion matches", counts: reviewCounts{approvals: 1}, minApprovals: 1, changesRequested: true, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, matchesReviewThreshold(tt.counts, tt.minApprovals, tt.changesRequested))
+		})
+	}
+}
+
+func TestKeepReviewThreshold(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	approved := createTestPR("approved", "url1")
+	dismissed := createTestPR("dismissed", "url2")
+	noReviews := createTestPR("no reviews", "url3")
+	notEnriched := createTestPR("not enriched", "url4")
+
+	pc := &PRChecker{username: "testuser", opts: Options{MinApprovals: 1}}
+	pc.setDetails(approved, &prDetails{reviews: []*github.PullRequestReview{
+		createTestReview("alice", reviewStateChangesRequested, base),
+		createTestReview("alice", reviewStateApproved, base.Add(time.Hour)),
+	}})
+	pc.setDetails(dismissed, &prDetails{reviews: []*github.PullRequestReview{
+		createTestReview("bob", reviewStateApproved, base),
+		createTestReview("bob", reviewStateDismissed, base.Add(time.Hour)),
+	}})
+	pc.setDetails(noReviews, &prDetails{})
+
+	issues := []*github.Issue{approved, dismissed, noReviews, notEnriched}
+	assert.True(t, pc.needsEnrichment(categoryCreated))
+	assert.Equal(t, []*github.Issue{approved}, pc.filterEnriched(categoryCreated, issues))
+
+	// Review requests are unaffected
+	assert.Equal(t, issues, pc.filterEnriched(categoryReviewer, issues))
+}
+
+func TestFilterExternal(t *testing.T) {
+	withAssociation := func(title, association string) *github.Issue {
+		issue := createTestPR(title, "https://github.com/o/r/pull/1")
+		if association != "" {
+			issue.AuthorAssociation = github.String(association)
+		}
+		return issue
+	}
+	owner := withAssociation("owner", "OWNER")
+	member := withAssociation("member", "MEMBER")
+	collaborator := withAssociation("collaborator", "COLLABORATOR")
+	contributor := withAssociation("contributor", "CONTRIBUTOR")
+	firstTimer := withAssociation("first timer", "FIRST_TIME_CONTRIBUTOR")
+	none := withAssociation("none", "NONE")
+	missing := withAssociation("missing", "")
+
+	assert.Equal(t, associationUnknown, authorAssociation(missing))
+	assert.Equal(t, "MEMBER", authorAssociation(member))
+
+	issues := []*github.Issue{owner, member, collaborator, contributor, firstTimer, none, missing}
+	assert.Equal(t, []*github.Issue{contributor, firstTimer, none}, filterExternal(issues))
+
+	pc := &PRChecker{username: "testuser", opts: Options{ExternalOnly: true}}
+	for _, cat := range []string{categoryCreated, categoryReviewer} {
+		got, err := pc.filterIssues(context.Background(), cat, issues)
+		assert.NoError(t, err)
+		assert.Equal(t, []*github.Issue{contributor, firstTimer, none}, got)
+	}
+}
@@ -0,0 +1,361 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/google/go-github/v67/github"
+)
+
+// errGraphQLUnavailable is returned when a client cannot make GraphQL requests
+var errGraphQLUnavailable = errors.New("GraphQL API is not available")
+
+// graphQLRequester is implemented by clients that can also query the GraphQL API
+type graphQLRequester interface {
+	graphQL(ctx context.Context, query string, variables map[string]interface{}, response interface{}) error
+}
+
+// queryGraphQL runs a GraphQL query through client, decoding its data into response
+func queryGraphQL(ctx context.Context, client GitHubClient, query string, variables map[string]interface{}, response interface{}) error {
+	requester, ok := client.(graphQLRequester)
+	if !ok {
+		return errGraphQLUnavailable
+	}
+	return requester.graphQL(ctx, query, variables, response)
+}
+
+func (c *githubRESTClient) graphQL(ctx context.Context, query string, variables map[string]interface{}, response interface{}) error {
+	if c.gql == nil {
+		return errGraphQLUnavailable
+	}
+	return c.gql.DoWithContext(ctx, query, variables, response)
+}
+
+func (c *retryingClient) graphQL(ctx context.Context, query string, variables map[string]interface{}, response interface{}) error {
+	return c.retry(ctx, func() error {
+		return queryGraphQL(ctx, c.client, query, variables, response)
+	})
+}
+
+// pullRequestSearchQuery fetches a category's PRs together with the fields enrichment
+// would otherwise request per PR over REST
+const pullRequestSearchQuery = `query($query: String!, $first: Int!, $after: String, $perPR: Int!) {
+  search(query: $query, type: ISSUE, first: $first, after: $after) {
+    issueCount
+    pageInfo { hasNextPage endCursor }
+    nodes {
+      ... on PullRequest {
+        number
+        title
+        body
+        url
+        isDraft
+        additions
+        deletions
+        changedFiles
+        mergeable
+        authorAssociation
+        createdAt
+        updatedAt
+        author { login }
+        labels(first: $perPR) {
+          nodes { name color }
+        }
+        reviews(last: $perPR) {
+          nodes { author { login } state submittedAt }
+        }
+        comments(last: 1) {
+          totalCount
+          nodes { author { login } createdAt }
+        }
+        reviewThreads(first: $perPR) {
+          nodes { isResolved }
+        }
+        commits(last: 1) {
+          nodes { commit { committedDate statusCheckRollup { state } } }
+        }
+      }
+    }
+  }
+}`
+
+// graphQLActor is the author of a PR, review or comment; nil for deleted accounts
+type graphQLActor struct {
+	Login string `json:"login"`
+}
+
+// graphQLPullRequest is a search result of pullRequestSearchQuery
+type graphQLPullRequest struct {
+	Number            int           `json:"number"`
+	Title             string        `json:"title"`
+	Body              string        `json:"body"`
+	URL               string        `json:"url"`
+	IsDraft           bool          `json:"isDraft"`
+	Additions         int           `json:"additions"`
+	Deletions         int           `json:"deletions"`
+	ChangedFiles      int           `json:"changedFiles"`
+	Mergeable         string        `json:"mergeable"`
+	AuthorAssociation string        `json:"authorAssociation"`
+	CreatedAt         time.Time     `json:"createdAt"`
+	UpdatedAt         time.Time     `json:"updatedAt"`
+	Author            *graphQLActor `json:"author"`
+	Labels            struct {
+		Nodes []struct {
+			Name  string `json:"name"`
+			Color string `json:"color"`
+		} `json:"nodes"`
+	} `json:"labels"`
+	Reviews struct {
+		Nodes []struct {
+			Author      *graphQLActor `json:"author"`
+			State       string        `json:"state"`
+			SubmittedAt time.Time     `json:"submittedAt"`
+		} `json:"nodes"`
+	} `json:"reviews"`
+	Comments struct {
+		TotalCount int `json:"totalCount"`
+		Nodes      []struct {
+			Author    *graphQLActor `json:"author"`
+			CreatedAt time.Time     `json:"createdAt"`
+		} `json:"nodes"`
+	} `json:"comments"`
+	ReviewThreads struct {
+		Nodes []reviewThread `json:"nodes"`
+	} `json:"reviewThreads"`
+	Commits struct {
+		Nodes []struct {
+			Commit struct {
+				CommittedDate     time.Time `json:"committedDate"`
+				StatusCheckRollup *struct {
+					State string `json:"state"`
+				} `json:"statusCheckRollup"`
+			} `json:"commit"`
+		} `json:"nodes"`
+	} `json:"commits"`
+}
+
+// pullRequestSearchResponse is the data returned for pullRequestSearchQuery
+type pullRequestSearchResponse struct {
+	Search struct {
+		IssueCount int `json:"issueCount"`
+		PageInfo   struct {
+			HasNextPage bool   `json:"hasNextPage"`
+			EndCursor   string `json:"endCursor"`
+		} `json:"pageInfo"`
+		Nodes []graphQLPullRequest `json:"nodes"`
+	} `json:"search"`
+}
+
+// user converts a GraphQL actor into the REST user model, keeping nil for deleted accounts
+func (a *graphQLActor) user() *github.User {
+	if a == nil {
+		return nil
+	}
+	return &github.User{Login: github.String(a.Login)}
+}
+
+// issue converts the PR into the issue model returned by the REST search
+func (pr *graphQLPullRequest) issue() *github.Issue {
+	var labels []*github.Label
+	for _, node := range pr.Labels.Nodes {
+		labels = append(labels, &github.Label{Name: github.String(node.Name), Color: github.String(node.Color)})
+	}
+	return &github.Issue{
+		Number:            github.Int(pr.Number),
+		Title:             github.String(pr.Title),
+		Body:              github.String(pr.Body),
+		HTMLURL:           github.String(pr.URL),
+		PullRequestLinks:  &github.PullRequestLinks{HTMLURL: github.String(pr.URL)},
+		Draft:             github.Bool(pr.IsDraft),
+		AuthorAssociation: github.String(pr.AuthorAssociation),
+		User:              pr.Author.user(),
+		Labels:            labels,
+		Comments:          github.Int(pr.Comments.TotalCount),
+		CreatedAt:         &github.Timestamp{Time: pr.CreatedAt},
+		UpdatedAt:         &github.Timestamp{Time: pr.UpdatedAt},
+	}
+}
+
+// reviews converts the PR's reviews into the REST review model
+func (pr *graphQLPullRequest) reviews() []*github.PullRequestReview {
+	reviews := make([]*github.PullRequestReview, 0, len(pr.Reviews.Nodes))
+	for _, node := range pr.Reviews.Nodes {
+		reviews = append(reviews, &github.PullRequestReview{
+			User:        node.Author.user(),
+			State:       github.String(node.State),
+			SubmittedAt: &github.Timestamp{Time: node.SubmittedAt},
+		})
+	}
+	return reviews
+}
+
+// comments converts the PR's comments into the REST comment model
+func (pr *graphQLPullRequest) comments() []*github.IssueComment {
+	comments := make([]*github.IssueComment, 0, len(pr.Comments.Nodes))
+	for _, node := range pr.Comments.Nodes {
+		comments = append(comments, &github.IssueComment{
+			User:      node.Author.user(),
+			CreatedAt: &github.Timestamp{Time: node.CreatedAt},
+		})
+	}
+	return comments
+}
+
+// checks returns the rolled-up state of the PR's latest commit
+func (pr *graphQLPullRequest) checks() checkStatus {
+	if len(pr.Commits.Nodes) == 0 || pr.Commits.Nodes[0].Commit.StatusCheckRollup == nil {
+		return checkStatusNone
+	}
+	switch pr.Commits.Nodes[0].Commit.StatusCheckRollup.State {
+	case "SUCCESS":
+		return checkStatusPassing
+	case "FAILURE", "ERROR":
+		return checkStatusFailing
+	case "PENDING", "EXPECTED":
+		return checkStatusPending
+	default:
+		return checkStatusNone
+	}
+}
+
+// mergeStatus returns the PR's merge status from its GraphQL mergeable state
+func (pr *graphQLPullRequest) mergeStatus() string {
+	switch pr.Mergeable {
+	case "MERGEABLE":
+		return mergeStatusMergeable
+	case "CONFLICTING":
+		return mergeStatusConflicting
+	default:
+		return mergeStatusUnknown
+	}
+}
+
+// graphQLDetails returns the per-PR data the enabled options require, as fetchDetails would
+func (pc *PRChecker) graphQLDetails(category string, pr *graphQLPullRequest) *prDetails {
+	reviews := pr.reviews()
+	details := &prDetails{reviews: reviews, decision: reviewDecision(reviews)}
+	if category == categoryCreated && pc.opts.Activity {
+		details.lastActor = lastActor(pr.comments(), details.reviews)
+	}
+	if pc.fetchesChecks() {
+		details.checks = pr.checks()
+	}
+	if pc.fetchesThreads() {
+		details.unresolved = countUnresolved(pr.ReviewThreads.Nodes)
+	}
+	if pc.fetchesDiffStat(category) {
+		details.diffStat = &diffStat{additions: pr.Additions, deletions: pr.Deletions, changedFiles: pr.ChangedFiles}
+	}
+	if pc.fetchesMergeStatus(category) {
+		details.mergeStatus = pr.mergeStatus()
+	}
+	if category == categoryReReview && len(pr.Commits.Nodes) > 0 {
+		details.reReview = needsReReview(details.reviews, pr.Commits.Nodes[0].Commit.CommittedDate, pc.username)
+	}
+	return details
+}
+
+// fetchSearchGraphQL fetches the PRs matching a category's search query and their
+// details over GraphQL, following pages as the REST search does
+func (pc *PRChecker) fetchSearchGraphQL(ctx context.Context, category, query string) (*github.IssuesSearchResult, error) {
+	variables := map[string]interface{}{
+		"query": decodeSearchQuery(query) + " " + pc.searchSortQualifier(),
+		"first": pc.searchPageSize(),
+		"perPR": enrichPerPage,
+	}
+
+	enrich := pc.needsEnrichment(category)
+	result := &github.IssuesSearchResult{}
+	for page := 1; page <= maxSearchPages; page++ {
+		var response pullRequestSearchResponse
+		if err := queryGraphQL(ctx, pc.client, pullRequestSearchQuery, variables, &response); err != nil {
+			return nil, err
+		}
+		if page == 1 {
+			result.Total = github.Int(response.Search.IssueCount)
+		}
+		for i := range response.Search.Nodes {
+			pr := &response.Search.Nodes[i]
+			if pr.URL == "" {
+				continue // Not a pull request, which the query never matches
+			}
+			issue := pr.issue()
+			if enrich {
+				pc.setDetails(issue, pc.graphQLDetails(category, pr))
+			}
+			result.Issues = append(result.Issues, issue)
+		}
+
+		info := response.Search.PageInfo
+		if !info.HasNextPage || info.EndCursor == "" || (pc.opts.Limit > 0 && len(result.Issues) >= pc.opts.Limit) {
+			return result, nil
+		}
+		variables["after"] = info.EndCursor
+	}
+	pc.debugf("%s search stopped after %d pages with %d of %d results", category, maxSearchPages, len(result.Issues), result.GetTotal())
+	return result, nil
+}
+
+// isGraphQLUnavailable reports whether err means the GraphQL path cannot be used, such as
+// a client without GraphQL support or a server rejecting the query's fields
+func isGraphQLUnavailable(err error) bool {
+	var gqlErr *api.GraphQLError
+	return errors.Is(err, errGraphQLUnavailable) || errors.As(err, &gqlErr)
+}
+
+// searchPullRequests fetches a category's PRs, over GraphQL with their details when
+// --graphql is set and REST otherwise. It reports whether the details were fetched too,
+// falling back to REST when GraphQL is unavailable. Review requests also include those
+// sent to --team.
+func (pc *PRChecker) searchPullRequests(ctx context.Context, category string) (*github.IssuesSearchResult, bool, error) {
+	query, err := pc.buildSearchQuery(category)
+	if err != nil {
+		return nil, false, err
+	}
+	result, enriched, err := pc.searchQuery(ctx, category, query)
+	if err != nil || !pc.searchesTeam(category) {
+		return result, enriched, err
+	}
+
+	query, err = pc.buildTeamSearchQuery()
+	if err != nil {
+		return nil, false, err
+	}
+	team, teamEnriched, err := pc.searchQuery(ctx, category, query)
+	if err != nil {
+		return nil, false, err
+	}
+	return mergeSearchResults(result, team), enriched && teamEnriched, nil
+}
+
+// searchQuery fetches the PRs matching one of a category's search queries as
+// searchPullRequests describes
+func (pc *PRChecker) searchQuery(ctx context.Context, category, query string) (*github.IssuesSearchResult, bool, error) {
+	if pc.opts.GraphQL {
+		result, err := pc.fetchSearchGraphQL(ctx, category, query)
+		if err == nil {
+			return result, true, nil
+		}
+		if !isGraphQLUnavailable(err) {
+			return nil, false, fmt.Errorf("failed to fetch pull requests: %w", err)
+		}
+		pc.debugf("falling back to REST for %s PRs: %v", category, err)
+	}
+
+	result, err := pc.fetchSearch(ctx, category, query)
+	if err != nil {
+		return nil, false, err
+	}
+	pc.progress.clear()
+	fetched := len(result.Issues)
+	result.Issues = keepPullRequests(os.Stderr, category, result.Issues)
+	if result.Total != nil {
+		// The search total counted the skipped results too, and it feeds the header count
+		result.Total = github.Int(max(0, result.GetTotal()-(fetched-len(result.Issues))))
+	}
+	return result, false, nil
+}
@@ -0,0 +1,228 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/google/go-github/v67/github"
+	"github.com/stretchr/testify/assert"
+)
+
+const testSearchResponse = `{"search": {"issueCount": 7, "nodes": [
+	{
+		"number": 42,
+		"title": "Add feature",
+		"body": "Details",
+		"url": "https://github.com/owner/repo/pull/42",
+		"isDraft": true,
+		"additions": 12,
+		"deletions": 5,
+		"changedFiles": 2,
+		"authorAssociation": "CONTRIBUTOR",
+		"createdAt": "2024-01-01T00:00:00Z",
+		"updatedAt": "2024-01-02T00:00:00Z",
+		"author": {"login": "testuser"},
+		"labels": {"nodes": [{"name": "bug", "color": "d73a4a"}]},
+		"reviews": {"nodes": [
+			{"author": {"login": "alice"}, "state": "APPROVED", "submittedAt": "2024-01-01T01:00:00Z"},
+			{"author": null, "state": "CHANGES_REQUESTED", "submittedAt": "2024-01-01T02:00:00Z"}
+		]},
+		"comments": {"totalCount": 3, "nodes": [{"author": {"login": "bob"}, "createdAt": "2024-01-01T03:00:00Z"}]},
+		"reviewThreads": {"nodes": [{"isResolved": false}, {"isResolved": true}]},
+		"commits": {"nodes": [{"commit": {"statusCheckRollup": {"state": "FAILURE"}}}]}
+	},
+	{}
+]}}`
+
+// failingGraphQLClient rejects every GraphQL query with err
+type failingGraphQLClient struct {
+	MockGitHubClient
+	err error
+}
+
+func (c *failingGraphQLClient) graphQL(ctx context.Context, query string, variables map[string]interface{}, response interface{}) error {
+	return c.err
+}
+
+func TestSearchPullRequestsGraphQLDetails(t *testing.T) {
+	client := &graphQLClient{data: testSearchResponse}
+	pc := &PRChecker{
+		client:   client,
+		username: "testuser",
+		opts:     Options{GraphQL: true, Activity: true, FailingChecks: true, Unresolved: true, DiffStat: true},
+	}
+
+	result, enriched, err := pc.searchPullRequests(context.Background(), categoryCreated)
+	assert.NoError(t, err)
+	assert.True(t, enriched)
+	assert.Equal(t, 7, result.GetTotal())
+	issues := result.Issues
+	assert.Equal(t, "is:open is:pr archived:false author:testuser draft:false sort:updated-desc", client.variables["query"])
+
+	// The result decodes into the same issue model the REST search returns
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	updated := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, []*github.Issue{{
+		Number:            github.Int(42),
+		Title:             github.String("Add feature"),
+		Body:              github.String("Details"),
+		HTMLURL:           github.String("https://github.com/owner/repo/pull/42"),
+		PullRequestLinks:  &github.PullRequestLinks{HTMLURL: github.String("https://github.com/owner/repo/pull/42")},
+		Draft:             github.Bool(true),
+		AuthorAssociation: github.String("CONTRIBUTOR"),
+		User:              &github.User{Login: github.String("testuser")},
+		Labels:            []*github.Label{{Name: github.String("bug"), Color: github.String("d73a4a")}},
+		Comments:          github.Int(3),
+		CreatedAt:         &github.Timestamp{Time: created},
+		UpdatedAt:         &github.Timestamp{Time: updated},
+	}}, issues)
+	assert.Equal(t, "owner/repo", repoFromIssue(issues[0]))
+
+	// Details match what enrichIssues would have recorded over REST
+	details := pc.detailsFor(issues[0])
+	assert.NotNil(t, details)
+	assert.Equal(t, "bob", details.lastActor)
+	assert.Equal(t, checkStatusFailing, details.checks)
+	assert.Equal(t, 1, details.unresolved)
+	assert.Equal(t, &diffStat{additions: 12, deletions: 5, changedFiles: 2}, details.diffStat)
+	assert.Equal(t, reviewCounts{approvals: 1}, countReviews(details.reviews))
+	assert.Nil(t, details.reviews[1].User)
+}
+
+// pagedGraphQLClient answers successive GraphQL queries with successive pages, recording
+// the cursor each query asked for
+type pagedGraphQLClient struct {
+	MockGitHubClient
+	pages  []string
+	afters []interface{}
+}
+
+func (c *pagedGraphQLClient) graphQL(ctx context.Context, query string, variables map[string]interface{}, response interface{}) error {
+	c.afters = append(c.afters, variables["after"])
+	page := c.pages[len(c.afters)-1]
+	return json.Unmarshal([]byte(page), response)
+}
+
+func TestFetchSearchGraphQLPages(t *testing.T) {
+	pages := []string{
+		`{"search": {"issueCount": 2, "pageInfo": {"hasNextPage": true, "endCursor": "c1"}, "nodes": [{"number": 1, "url": "https://github.com/owner/repo/pull/1"}]}}`,
+		`{"search": {"issueCount": 2, "pageInfo": {"hasNextPage": false, "endCursor": "c2"}, "nodes": [{"number": 2, "url": "https://github.com/owner/repo/pull/2"}]}}`,
+	}
+
+	tests := []struct {
+		name       string
+		limit      int
+		wantAfters []interface{}
+		wantCount  int
+	}{
+		{name: "follows every page", wantAfters: []interface{}{nil, "c1"}, wantCount: 2},
+		{name: "stops once the limit is reached", limit: 1, wantAfters: []interface{}{nil}, wantCount: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &pagedGraphQLClient{pages: pages}
+			pc := &PRChecker{client: client, username: "testuser", opts: Options{Limit: tt.limit}}
+
+			result, err := pc.fetchSearchGraphQL(context.Background(), categoryCreated, "author:testuser")
+			assert.NoError(t, err)
+			assert.Equal(t, 2, result.GetTotal())
+			assert.Len(t, result.Issues, tt.wantCount)
+			assert.Equal(t, tt.wantAfters, client.afters)
+		})
+	}
+}
+
+func TestGraphQLChecks(t *testing.T) {
+	tests := []struct {
+		state string
+		want  checkStatus
+	}{
+		{state: "SUCCESS", want: checkStatusPassing},
+		{state: "FAILURE", want: checkStatusFailing},
+		{state: "ERROR", want: checkStatusFailing},
+		{state: "PENDING", want: checkStatusPending},
+		{state: "EXPECTED", want: checkStatusPending},
+		{state: "", want: checkStatusNone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.state, func(t *testing.T) {
+			var pr graphQLPullRequest
+			data := `{"commits": {"nodes": [{"commit": {"statusCheckRollup": {"state": "` + tt.state + `"}}}]}}`
+			assert.NoError(t, json.Unmarshal([]byte(data), &pr))
+			assert.Equal(t, tt.want, pr.checks())
+		})
+	}
+
+	assert.Equal(t, checkStatusNone, (&graphQLPullRequest{}).checks())
+}
+
+func TestSearchPullRequests(t *testing.T) {
+	restResult := createTestPRList(createTestPR("REST PR", "https://github.com/owner/repo/pull/1"))
+
+	tests := []struct {
+		name         string
+		client       GitHubClient
+		graphQL      bool
+		wantTitle    string
+		wantEnriched bool
+		wantErr      bool
+	}{
+		{name: "rest by default", client: &graphQLClient{MockGitHubClient: MockGitHubClient{response: restResult}, data: testSearchResponse}, wantTitle: "REST PR"},
+		{name: "graphql", client: &graphQLClient{data: testSearchResponse}, graphQL: true, wantTitle: "Add feature", wantEnriched: true},
+		{name: "fallback without graphql support", client: &MockGitHubClient{response: restResult}, graphQL: true, wantTitle: "REST PR"},
+		{
+			name:      "fallback when the query is rejected",
+			client:    &failingGraphQLClient{MockGitHubClient: MockGitHubClient{response: restResult}, err: &api.GraphQLError{}},
+			graphQL:   true,
+			wantTitle: "REST PR",
+		},
+		{
+			name:    "other errors are reported",
+			client:  &failingGraphQLClient{MockGitHubClient: MockGitHubClient{response: restResult}, err: context.DeadlineExceeded},
+			graphQL: true,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pc := &PRChecker{client: tt.client, username: "testuser", opts: Options{GraphQL: tt.graphQL}}
+			result, enriched, err := pc.searchPullRequests(context.Background(), categoryCreated)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, result.Issues, 1)
+			assert.Equal(t, tt.wantTitle, result.Issues[0].GetTitle())
+			assert.Equal(t, tt.wantEnriched, enriched)
+		})
+	}
+}
+
+func TestSearchPullRequestsGraphQLSince(t *testing.T) {
+	since, err := parseSince("2024-01-01")
+	assert.NoError(t, err)
+	client := &graphQLClient{data: testSearchResponse}
+	pc := &PRChecker{client: client, username: "testuser", opts: Options{GraphQL: true, Since: since}}
+
+	_, _, err = pc.searchPullRequests(context.Background(), categoryCreated)
+	assert.NoError(t, err)
+	assert.Equal(t, "is:open is:pr archived:false author:testuser draft:false updated:>=2024-01-01 sort:updated-desc", client.variables["query"])
+}
+
+func TestSearchPullRequestsGraphQLQueryExtra(t *testing.T) {
+	opts, err := parseOptions([]string{"--graphql", "--query-extra", "label:bug"})
+	assert.NoError(t, err)
+	client := &graphQLClient{data: testSearchResponse}
+	pc := &PRChecker{client: client, username: "testuser", opts: opts}
+
+	_, _, err = pc.searchPullRequests(context.Background(), categoryCreated)
+	assert.NoError(t, err)
+	assert.Equal(t, "is:open is:pr archived:false author:testuser draft:false label:bug sort:updated-desc", client.variables["query"])
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/google/go-github/v67/github"
+)
+
+// repoGroup holds the PRs of one repository within a section
+type repoGroup struct {
+	repo   string
+	issues []*github.Issue
+}
+
+// groupByRepo buckets issues by their "owner/name" repository. Repositories are in
+// alphabetical order and each one's PRs are most recently updated first.
+func groupByRepo(issues []*github.Issue) []repoGroup {
+	byRepo := make(map[string][]*github.Issue)
+	for _, issue := range issues {
+		repo := repoFromIssue(issue)
+		byRepo[repo] = append(byRepo[repo], issue)
+	}
+
+	groups := make([]repoGroup, 0, len(byRepo))
+	for repo, list := range byRepo {
+		sortIssues(list, timeFieldUpdated, true)
+		groups = append(groups, repoGroup{repo: repo, issues: list})
+	}
+	slices.SortFunc(groups, func(a, b repoGroup) int {
+		return strings.Compare(strings.ToLower(a.repo), strings.ToLower(b.repo))
+	})
+	return groups
+}
+
+// displayGroupedIssues prints a section's PRs under a subheader per repository
+func (pc *PRChecker) displayGroupedIssues(issues []*github.Issue, category string) error {
+	for _, group := range groupByRepo(issues) {
+		pc.formatter.groupStyle.Fprintf(pc.writer(), "%s\n", pc.redact.repo(group.repo))
+		if err := pc.displayIssues(group.issues, category); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v67/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupByRepo(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cliOld := createTestPRWithNumber(1, "https://github.com/cli/cli/pull/1", base)
+	cliNew := createTestPRWithNumber(2, "https://github.com/cli/cli/pull/2", base.Add(time.Hour))
+	zoo := createTestPRWithNumber(3, "https://github.com/zoo/app/pull/3", base.Add(2*time.Hour))
+	appNew := createTestPRWithNumber(4, "https://github.com/Acme/app/pull/4", base.Add(3*time.Hour))
+	appOld := createTestPRWithNumber(5, "https://github.com/Acme/app/pull/5", base)
+
+	groups := groupByRepo([]*github.Issue{cliOld, zoo, appOld, cliNew, appNew})
+
+	// Repositories are alphabetical regardless of case, with the latest PRs first in each
+	assert.Equal(t, []repoGroup{
+		{repo: "Acme/app", issues: []*github.Issue{appNew, appOld}},
+		{repo: "cli/cli", issues: []*github.Issue{cliNew, cliOld}},
+		{repo: "zoo/app", issues: []*github.Issue{zoo}},
+	}, groups)
+}
+
+func TestGroupByRepoEmpty(t *testing.T) {
+	assert.Empty(t, groupByRepo(nil))
+}
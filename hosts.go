@@ -0,0 +1,205 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/google/go-github/v67/github"
+)
+
+// hostList collects the values of the repeatable --host flag
+type hostList []string
+
+func (h *hostList) String() string {
+	return strings.Join(*h, ",")
+}
+
+func (h *hostList) Set(value string) error {
+	if value == "" {
+		return fmt.Errorf("host must not be empty")
+	}
+	*h = append(*h, value)
+	return nil
+}
+
+// envGHHost selects the default host, as it does for gh itself
+const envGHHost = "GH_HOST"
+
+// defaultHost returns the host queried when no --host is given: GH_HOST when set, or an
+// empty string leaving the choice to gh's configured default host
+func defaultHost(getenv func(string) string) string {
+	return getenv(envGHHost)
+}
+
+// hostConnector creates an authenticated client for a host, returning the name of the
+// token source alongside it
+type hostConnector func(host string, opts Options) (GitHubClient, string, error)
+
+// hostResult holds the collected results of one host
+type hostResult struct {
+	checker    *PRChecker
+	categories []string
+	results    map[string][]*github.Issue
+}
+
+// NewPRCheckers creates a PRChecker for each --host, or a single one for the default
+// host when no host is given
+func NewPRCheckers(opts Options) ([]*PRChecker, error) {
+	if len(opts.Hosts) == 0 {
+		pc, err := NewPRChecker(opts)
+		if err != nil {
+			return nil, err
+		}
+		return []*PRChecker{pc}, nil
+	}
+	return newHostCheckers(opts, initializeGitHubClient)
+}
+
+// newHostCheckers creates a PRChecker per host with a client from connect. The retry
+// budget is shared since it limits retries across the whole run.
+func newHostCheckers(opts Options, connect hostConnector) ([]*PRChecker, error) {
+	budget := newRetryBudget(opts.MaxRetries)
+	var redact *redactor
+	if opts.Redact {
+		redact = newRedactor() // Shared so placeholders stay consistent across hosts
+	}
+	checkers := make([]*PRChecker, 0, len(opts.Hosts))
+	for _, host := range opts.Hosts {
+		client, source, err := connect(host, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize GitHub client for %s: %w", host, err)
+		}
+		pc, err := newPRChecker(opts, host, client, source, budget)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", host, err)
+		}
+		pc.redact = redact
+		checkers = append(checkers, pc)
+	}
+	return checkers, nil
+}
+
+// runHosts runs every checker and renders their results together. Hosts are queried one
+// after another so their progress output does not interleave.
+func runHosts(checkers []*PRChecker) (err error) {
+	if checkers[0].opts.PrintQuery {
+		for _, pc := range checkers {
+			if err := pc.printQueries(os.Stderr); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	width := terminalWidth()
+	if path := checkers[0].opts.Output; path != "" {
+		f, err := createOutput(path)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if closeErr := f.Close(); err == nil {
+				err = closeErr
+			}
+		}()
+		for _, pc := range checkers {
+			pc.out = f
+		}
+		width = 0 // Files are laid out at the fallback width
+	}
+	for _, pc := range checkers {
+		pc.width = width
+	}
+
+	if len(checkers) == 1 {
+		return checkers[0].Run()
+	}
+
+	// Hosts share one seen store since they are saved to the same file
+	lead := checkers[0]
+	if err := lead.loadSeen(); err != nil {
+		return err
+	}
+
+	hostResults := make([]hostResult, 0, len(checkers))
+	for _, pc := range checkers {
+		pc.seen = lead.seen
+		categories, results, err := pc.collect(nil)
+		if err != nil {
+			return fmt.Errorf("%s: %w", pc.host, err)
+		}
+		pc.countPending(results)
+		hostResults = append(hostResults, hostResult{checker: pc, categories: categories, results: results})
+	}
+	defer func() {
+		for _, pc := range checkers {
+			pc.warnRateLimit(os.Stderr)
+		}
+	}()
+
+	if lead.opts.MarkAllSeen {
+		return lead.markAllSeen(hostResults)
+	}
+	if err := displayResults(lead.writer(), hostResults); err != nil {
+		return err
+	}
+	return lead.finishListed(hostResults)
+}
+
+// flattenResults returns the PRs of every category in a single list
+func flattenResults(results map[string][]*github.Issue) []*github.Issue {
+	var issues []*github.Issue
+	for _, list := range results {
+		issues = append(issues, list...)
+	}
+	return issues
+}
+
+// resultMaps returns the results of each host
+func resultMaps(hostResults []hostResult) []map[string][]*github.Issue {
+	maps := make([]map[string][]*github.Issue, 0, len(hostResults))
+	for _, hr := range hostResults {
+		maps = append(maps, hr.results)
+	}
+	return maps
+}
+
+// hostRateLimits returns the rate limit last reported to each host's client, keyed by
+// host, when --show-rate-limit is set
+func hostRateLimits(hostResults []hostResult) map[string]*rateLimit {
+	rates := make(map[string]*rateLimit)
+	for _, hr := range hostResults {
+		if !hr.checker.opts.ShowRateLimit {
+			continue
+		}
+		if rate := rateLimitOf(hr.checker.client); rate != nil {
+			rates[hr.checker.host] = rate
+		}
+	}
+	return rates
+}
+
+// mergeHostRecords combines each category's results across hosts into records, tagged
+// with their host when there are several, returning the categories in first-seen order
+func mergeHostRecords(hostResults []hostResult) ([]string, map[string][]prRecord) {
+	var categories []string
+	records := make(map[string][]prRecord)
+	for _, hr := range hostResults {
+		hostRecords := recordsByCategory(hr.categories, hr.results)
+		hr.checker.redact.records(hostRecords)
+		for _, cat := range hr.categories {
+			if _, ok := records[cat]; !ok {
+				categories = append(categories, cat)
+				records[cat] = []prRecord{}
+			}
+			for _, record := range hostRecords[cat] {
+				if len(hostResults) > 1 {
+					record.Host = hr.checker.host
+				}
+				records[cat] = append(records[cat], record)
+			}
+		}
+	}
+	return categories, records
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-github/v67/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewHostCheckers(t *testing.T) {
+	clients := map[string]*MockGitHubClient{
+		"github.com": {
+			response: &github.User{Login: github.String("octocat")},
+		},
+		"ghe.example.com": {
+			response: &github.User{Login: github.String("octocat-corp")},
+		},
+	}
+	var connected []string
+	connect := func(host string, opts Options) (GitHubClient, string, error) {
+		connected = append(connected, host)
+		client, ok := clients[host]
+		if !ok {
+			return nil, "", fmt.Errorf("unknown host %s", host)
+		}
+		return client, tokenSourceGhAuth, nil
+	}
+
+	checkers, err := newHostCheckers(Options{Hosts: []string{"github.com", "ghe.example.com"}}, connect)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"github.com", "ghe.example.com"}, connected)
+	assert.Len(t, checkers, 2)
+	assert.Equal(t, "github.com", checkers[0].host)
+	assert.Equal(t, "octocat", checkers[0].username)
+	assert.Equal(t, "ghe.example.com", checkers[1].host)
+	assert.Equal(t, "octocat-corp", checkers[1].username)
+
+	// The retry budget spans every host
+	budget := checkers[0].client.(*retryingClient).budget
+	assert.Same(t, budget, checkers[1].client.(*retryingClient).budget)
+
+	_, err = newHostCheckers(Options{Hosts: []string{"github.com", "unknown.example.com"}}, connect)
+	assert.ErrorContains(t, err, "unknown.example.com")
+}
+
+func TestDisplayHostsJSON(t *testing.T) {
+	newChecker := func(host, username string, issues ...*github.Issue) *PRChecker {
+		return &PRChecker{
+			client:   &MockGitHubClient{response: createTestPRList(issues...)},
+			username: username,
+			host:     host,
+			opts:     Options{JSON: true, Categories: []string{categoryCreated}},
+		}
+	}
+	checkers := []*PRChecker{
+		newChecker("github.com", "octocat", createTestPR("Public PR", "https://github.com/o/r/pull/1")),
+		newChecker("ghe.example.com", "octocat-corp", createTestPR("Internal PR", "https://ghe.example.com/o/r/pull/2")),
+	}
+
+	var hostResults []hostResult
+	for _, pc := range checkers {
+		categories, results, err := pc.collect(nil)
+		assert.NoError(t, err)
+		hostResults = append(hostResults, hostResult{checker: pc, categories: categories, results: results})
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, displayResults(&buf, hostResults))
+
+	var decoded struct {
+		Created []prRecord  `json:"created"`
+		Summary jsonSummary `json:"summary"`
+	}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Len(t, decoded.Created, 2)
+	assert.Equal(t, "Public PR", decoded.Created[0].Title)
+	assert.Equal(t, "github.com", decoded.Created[0].Host)
+	assert.Equal(t, "Internal PR", decoded.Created[1].Title)
+	assert.Equal(t, "ghe.example.com", decoded.Created[1].Host)
+	assert.Equal(t, 2, decoded.Summary.Total)
+
+	header, err := checkers[1].sectionHeader(categoryCreated)
+	assert.NoError(t, err)
+	assert.Equal(t, "🔨 Pull Requests Created by octocat-corp on ghe.example.com (1)", header)
+}
+
+func TestDisplayHostsHTML(t *testing.T) {
+	var hostResults []hostResult
+	for _, host := range []string{"github.com", "ghe.example.com"} {
+		pc := &PRChecker{username: "octocat", host: host, opts: Options{HTML: true}}
+		hostResults = append(hostResults, hostResult{checker: pc, categories: []string{categoryReviewer}})
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, displayResults(&buf, hostResults))
+
+	out := buf.String()
+	assert.Equal(t, 1, bytes.Count(buf.Bytes(), []byte("<!DOCTYPE html>")))
+	assert.Contains(t, out, "<h2>👀 Review Requests for octocat on github.com</h2>")
+	assert.Contains(t, out, "<h2>👀 Review Requests for octocat on ghe.example.com</h2>")
+}
+
+func TestMergeHostRecords(t *testing.T) {
+	newResult := func(host, url string) hostResult {
+		return hostResult{
+			checker:    &PRChecker{host: host},
+			categories: []string{categoryCreated},
+			results:    map[string][]*github.Issue{categoryCreated: {createTestPR("PR", url)}},
+		}
+	}
+
+	// A single host keeps the records untagged, as without --host
+	categories, records := mergeHostRecords([]hostResult{newResult("github.com", "https://github.com/o/r/pull/1")})
+	assert.Equal(t, []string{categoryCreated}, categories)
+	assert.Empty(t, records[categoryCreated][0].Host)
+
+	_, records = mergeHostRecords([]hostResult{
+		newResult("github.com", "https://github.com/o/r/pull/1"),
+		newResult("ghe.example.com", "https://ghe.example.com/o/r/pull/2"),
+	})
+	assert.Len(t, records[categoryCreated], 2)
+	assert.Equal(t, "github.com", records[categoryCreated][0].Host)
+	assert.Equal(t, "ghe.example.com", records[categoryCreated][1].Host)
+}
+
+func TestDefaultHost(t *testing.T) {
+	env := map[string]string{envGHHost: "ghe.example.com"}
+	assert.Equal(t, "ghe.example.com", defaultHost(func(key string) string { return env[key] }))
+	assert.Equal(t, "", defaultHost(func(string) string { return "" }))
+
+	// An enterprise GH_HOST skips GH_TOKEN, which belongs to github.com
+	t.Setenv("GH_TOKEN", "github-token")
+	t.Setenv("GH_ENTERPRISE_TOKEN", "enterprise-token")
+	token, _ := resolveHostToken(defaultHost(func(key string) string { return env[key] }), "")
+	assert.Equal(t, "enterprise-token", token)
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"html/template"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v67/github"
+)
+
+// htmlTemplate renders a standalone page with one table per category
+var htmlTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Pull requests for {{.Username}}</title>
+<style>
+body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2em; color: #1f2328; }
+table { border-collapse: collapse; width: 100%; margin-bottom: 2em; }
+th, td { text-align: left; padding: 6px 12px; border-bottom: 1px solid #d0d7de; }
+th { background: #f6f8fa; }
+a { color: #0969da; }
+.empty { color: #9a6700; }
+</style>
+</head>
+<body>
+{{- range .Sections}}
+<h2>{{.Icon}} {{.Description}} {{.Username}}{{with .Host}} on {{.}}{{end}}</h2>
+{{- if .PRs}}
+<table>
+<thead><tr><th>Title</th><th>Repository</th><th>Author</th><th>{{$.TimeLabel}}</th><th>URL</th></tr></thead>
+<tbody>
+{{- range .PRs}}
+<tr><td><a href="{{.URL}}">{{.Title}}</a></td><td>{{.Repo}}</td><td>{{if .AuthorURL}}<a href="{{.AuthorURL}}">{{.Author}}</a>{{else}}{{.Author}}{{end}}</td><td><time datetime="{{.Time.Format "2006-01-02T15:04:05Z07:00"}}">{{.TimeText}}</time></td><td><a href="{{.URL}}">{{.URL}}</a></td></tr>
+{{- end}}
+</tbody>
+</table>
+{{- else}}
+<p class="empty">No pull requests found</p>
+{{- end}}
+{{- end}}
+</body>
+</html>
+`))
+
+// htmlSection holds the data rendered for one category
+type htmlSection struct {
+	Icon        string
+	Description string
+	Username    string
+	Host        string // Set when several hosts are queried
+	PRs         []htmlRow
+}
+
+// htmlRow is a PR along with the timestamp selected for the time column
+type htmlRow struct {
+	prRecord
+	Time      time.Time
+	TimeText  string // Time as the table shows it, following --time-format and --tz
+	AuthorURL string // Profile of the author, empty when unknown or redacted
+}
+
+// profileURL returns the profile page of login on the host serving prURL, or an empty
+// string when either is missing. GitHub Apps link to their app page.
+func profileURL(prURL, login string) string {
+	u, err := url.Parse(prURL)
+	if err != nil || u.Scheme == "" || u.Host == "" || login == "" {
+		return ""
+	}
+	path := "/" + login
+	if name, ok := strings.CutSuffix(login, botSuffix); ok {
+		path = "/apps/" + name
+	}
+	return (&url.URL{Scheme: u.Scheme, Host: u.Host, Path: path}).String()
+}
+
+// writeHTML renders the results of one or more hosts as a standalone HTML page with one
+// section per host and category
+func writeHTML(w io.Writer, hostResults []hostResult) error {
+	var sections []htmlSection
+	for _, hr := range hostResults {
+		hostSections, err := hr.checker.htmlSections(hr.categories, hr.results)
+		if err != nil {
+			return err
+		}
+		sections = append(sections, hostSections...)
+	}
+	lead := hostResults[0].checker
+	return renderHTML(w, lead.redact.user(lead.username), timeFieldLabel(lead.opts.TimeField), sections)
+}
+
+// htmlSections builds the HTML sections for the results of each category
+func (pc *PRChecker) htmlSections(categories []string, results map[string][]*github.Issue) ([]htmlSection, error) {
+	now := pc.now()
+	sections := make([]htmlSection, 0, len(categories))
+	for _, cat := range categories {
+		icon, description, err := sectionTitle(cat)
+		if err != nil {
+			return nil, err
+		}
+		var rows []htmlRow
+		for _, issue := range results[cat] {
+			row := htmlRow{
+				prRecord: pc.redact.record(newPRRecord(issue)),
+				Time:     issueTime(issue, pc.opts.TimeField),
+				TimeText: pc.formatTime(issue, now),
+			}
+			if pc.redact == nil { // Placeholder logins would link to real accounts
+				row.AuthorURL = profileURL(row.URL, row.Author)
+			}
+			rows = append(rows, row)
+		}
+		sections = append(sections, htmlSection{
+			Icon:        icon,
+			Description: description,
+			Username:    pc.redact.user(pc.username),
+			Host:        pc.host,
+			PRs:         rows,
+		})
+	}
+	return sections, nil
+}
+
+// renderHTML writes the page for the given sections
+func renderHTML(w io.Writer, username, timeLabel string, sections []htmlSection) error {
+	return htmlTemplate.Execute(w, struct {
+		Username  string
+		TimeLabel string
+		Sections  []htmlSection
+	}{
+		Username:  username,
+		TimeLabel: timeLabel,
+		Sections:  sections,
+	})
+}
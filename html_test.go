@@ -0,0 +1,92 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v67/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteHTML(t *testing.T) {
+	pc := &PRChecker{username: "testuser"}
+	feature := createTestPRInRepo("Add feature", "owner/repo")
+	feature.User = &github.User{Login: github.String("octocat")}
+	results := map[string][]*github.Issue{
+		categoryCreated: {
+			feature,
+			createTestPRInRepo(`<script>alert("x")</script>`, "owner/other"),
+		},
+	}
+
+	var buf bytes.Buffer
+	err := writeHTML(&buf, []hostResult{{checker: pc, categories: []string{categoryCreated, categoryReviewer}, results: results}})
+	assert.NoError(t, err)
+
+	out := buf.String()
+	assert.Contains(t, out, "<!DOCTYPE html>")
+	assert.Contains(t, out, "<h2>🔨 Pull Requests Created by testuser</h2>")
+	assert.Contains(t, out, "<th>Title</th><th>Repository</th><th>Author</th><th>Updated</th><th>URL</th>")
+	assert.Contains(t, out, `<tr><td><a href="https://github.com/owner/repo/pull/1">Add feature</a></td><td>owner/repo</td>`+
+		`<td><a href="https://github.com/octocat">octocat</a></td>`)
+	assert.Contains(t, out, "<td>owner/other</td><td></td>") // No author link without a login
+	assert.Contains(t, out, `<a href="https://github.com/owner/repo/pull/1">https://github.com/owner/repo/pull/1</a>`)
+	assert.Contains(t, out, "&lt;script&gt;alert(&#34;x&#34;)&lt;/script&gt;")
+	assert.NotContains(t, out, "<script>")
+	assert.Contains(t, out, "<h2>👀 Review Requests for testuser</h2>\n<p class=\"empty\">No pull requests found</p>")
+}
+
+func TestWriteHTMLRedacted(t *testing.T) {
+	pc := &PRChecker{username: "testuser", redact: newRedactor()}
+	pr := createTestPRInRepo("Add feature", "owner/repo")
+	pr.User = &github.User{Login: github.String("octocat")}
+
+	var buf bytes.Buffer
+	assert.NoError(t, writeHTML(&buf, []hostResult{{checker: pc, categories: []string{categoryCreated}, results: map[string][]*github.Issue{categoryCreated: {pr}}}}))
+	assert.NotContains(t, buf.String(), "octocat")
+	assert.NotContains(t, buf.String(), `<a href="https://github.com/user`)
+}
+
+func TestProfileURL(t *testing.T) {
+	assert.Equal(t, "https://github.com/octocat", profileURL("https://github.com/owner/repo/pull/1", "octocat"))
+	assert.Equal(t, "https://ghe.example.com/octocat", profileURL("https://ghe.example.com/owner/repo/pull/1", "octocat"))
+	assert.Equal(t, "https://github.com/apps/dependabot", profileURL("https://github.com/owner/repo/pull/1", "dependabot[bot]"))
+	assert.Empty(t, profileURL("https://github.com/owner/repo/pull/1", ""))
+	assert.Empty(t, profileURL("not a url", "octocat"))
+}
+
+func TestWriteHTMLInvalidCategory(t *testing.T) {
+	pc := &PRChecker{username: "testuser"}
+
+	var buf bytes.Buffer
+	err := writeHTML(&buf, []hostResult{{checker: pc, categories: []string{"invalid"}, results: nil}})
+	assert.Error(t, err)
+}
+
+func TestWriteHTMLTimes(t *testing.T) {
+	now := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	tokyo := time.FixedZone("JST", 9*60*60)
+
+	tests := []struct {
+		name string
+		opts Options
+		want string
+	}{
+		{name: "relative to the clock", want: ">about 2 hours ago</time>"},
+		{name: "absolute in the time zone", opts: Options{TimeFormat: timeFormatAbsolute, Location: tokyo}, want: ">" + now.Add(-2*time.Hour).In(tokyo).Format(absoluteTimeLayout) + "</time>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pr := createTestPRInRepo("Add feature", "owner/repo")
+			pr.UpdatedAt = &github.Timestamp{Time: now.Add(-2 * time.Hour)}
+			pc := &PRChecker{username: "testuser", opts: tt.opts, clock: func() time.Time { return now }}
+
+			var buf bytes.Buffer
+			results := map[string][]*github.Issue{categoryCreated: {pr}}
+			assert.NoError(t, writeHTML(&buf, []hostResult{{checker: pc, categories: []string{categoryCreated}, results: results}}))
+			assert.Contains(t, buf.String(), tt.want)
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/jq"
+	"github.com/itchyny/gojq"
+)
+
+// jsonSummaryKey is the top-level key holding aggregate stats in JSON output
+const jsonSummaryKey = "summary"
+
+// jsonSummary holds aggregate stats so consumers need not recompute them
+type jsonSummary struct {
+	Counts       map[string]int `json:"counts"`        // Number of PRs per category
+	Total        int            `json:"total"`         // Sum of the per-category counts
+	OldestUpdate *time.Time     `json:"oldest_update"` // Least recent update across all PRs, null when empty
+}
+
+// newJSONSummary computes the aggregate stats for the records of the given categories
+func newJSONSummary(categories []string, records map[string][]prRecord) jsonSummary {
+	summary := jsonSummary{Counts: make(map[string]int, len(categories))}
+	for _, cat := range categories {
+		summary.Counts[cat] = len(records[cat])
+		summary.Total += len(records[cat])
+		for _, record := range records[cat] {
+			updated := record.UpdatedAt
+			if updated.IsZero() {
+				continue
+			}
+			if summary.OldestUpdate == nil || updated.Before(*summary.OldestUpdate) {
+				summary.OldestUpdate = &updated
+			}
+		}
+	}
+	return summary
+}
+
+// writeJSON renders the records as a JSON object with one array of PRs per category
+// alongside a summary object and any extra top-level values, such as the rate limit
+func writeJSON(w io.Writer, categories []string, records map[string][]prRecord, extras map[string]interface{}) error {
+	output := make(map[string]interface{}, len(categories)+len(extras)+1)
+	for _, cat := range categories {
+		output[cat] = records[cat]
+		if output[cat] == nil {
+			output[cat] = []prRecord{}
+		}
+	}
+	output[jsonSummaryKey] = newJSONSummary(categories, records)
+	for key, value := range extras {
+		output[key] = value
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(output)
+}
+
+// writeFilteredJSON renders the records like writeJSON, passing the document through the
+// jq expression expr first when it is set
+func writeFilteredJSON(w io.Writer, expr string, categories []string, records map[string][]prRecord, extras map[string]interface{}) error {
+	if expr == "" {
+		return writeJSON(w, categories, records, extras)
+	}
+
+	var buf bytes.Buffer
+	if err := writeJSON(&buf, categories, records, extras); err != nil {
+		return err
+	}
+	if err := jq.Evaluate(&buf, w, expr); err != nil {
+		return fmt.Errorf("failed to evaluate --jq expression: %w", err)
+	}
+	return nil
+}
+
+// validateJQ checks that expr is a valid jq expression so mistakes are reported before
+// any request is made
+func validateJQ(expr string) error {
+	query, err := gojq.Parse(expr)
+	if err != nil {
+		return err
+	}
+	_, err = gojq.Compile(query, gojq.WithEnvironLoader(func() []string { return nil }))
+	return err
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v67/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewJSONSummary(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	results := map[string][]*github.Issue{
+		categoryCreated: {
+			createTestPRWithNumber(1, "url1", base.Add(time.Hour)),
+			createTestPRWithNumber(2, "url2", base),
+		},
+		categoryReviewer: {
+			createTestPRWithNumber(3, "url3", base.Add(2*time.Hour)),
+		},
+	}
+
+	categories := []string{categoryCreated, categoryReviewer}
+	summary := newJSONSummary(categories, recordsByCategory(categories, results))
+	assert.Equal(t, map[string]int{categoryCreated: 2, categoryReviewer: 1}, summary.Counts)
+	assert.Equal(t, 3, summary.Total)
+	assert.Equal(t, &base, summary.OldestUpdate)
+
+	empty := newJSONSummary([]string{categoryCreated}, nil)
+	assert.Equal(t, map[string]int{categoryCreated: 0}, empty.Counts)
+	assert.Equal(t, 0, empty.Total)
+	assert.Nil(t, empty.OldestUpdate)
+}
+
+func TestWriteJSON(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	results := map[string][]*github.Issue{
+		categoryCreated: {
+			createTestPRWithNumber(1, "url1", base.Add(time.Hour)),
+			createTestPRWithNumber(2, "url2", base),
+		},
+	}
+
+	var buf bytes.Buffer
+	categories := []string{categoryCreated, categoryReviewer}
+	err := writeJSON(&buf, categories, recordsByCategory(categories, results), nil)
+	assert.NoError(t, err)
+
+	var decoded struct {
+		Created   []prRecord `json:"created"`
+		Requested []prRecord `json:"requested"`
+		Summary   struct {
+			Counts       map[string]int `json:"counts"`
+			Total        int            `json:"total"`
+			OldestUpdate *time.Time     `json:"oldest_update"`
+		} `json:"summary"`
+	}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+
+	assert.Len(t, decoded.Created, 2)
+	assert.Equal(t, 1, decoded.Created[0].Number)
+	assert.Equal(t, "url1", decoded.Created[0].URL)
+	assert.NotNil(t, decoded.Requested)
+	assert.Empty(t, decoded.Requested)
+	assert.Equal(t, map[string]int{categoryCreated: 2, categoryReviewer: 0}, decoded.Summary.Counts)
+	assert.Equal(t, 2, decoded.Summary.Total)
+	assert.True(t, base.Equal(*decoded.Summary.OldestUpdate))
+}
+
+func TestWriteJSONKeepsFullURL(t *testing.T) {
+	issue := createTestPRInRepo("PR", "owner/repo")
+	issue.Number = github.Int(123)
+
+	// The table shortens the link while structured output keeps the full URL
+	pc := &PRChecker{opts: Options{ShortURL: true, TruncateURL: true}}
+	assert.Equal(t, "owner/repo#123", pc.formatURL(issue, categoryCreated))
+
+	var buf bytes.Buffer
+	records := recordsByCategory([]string{categoryCreated}, map[string][]*github.Issue{categoryCreated: {issue}})
+	err := writeJSON(&buf, []string{categoryCreated}, records, nil)
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), `"url": "https://github.com/owner/repo/pull/1"`)
+}
+
+func TestWriteFilteredJSON(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	categories := []string{categoryCreated, categoryReviewer}
+	records := recordsByCategory(categories, map[string][]*github.Issue{
+		categoryCreated: {
+			createTestPRWithNumber(1, "url1", base.Add(time.Hour)),
+			createTestPRWithNumber(2, "url2", base),
+		},
+		categoryReviewer: {createTestPRWithNumber(3, "url3", base)},
+	})
+
+	tests := []struct {
+		name    string
+		expr    string
+		want    string
+		wantErr string
+	}{
+		{name: "scalar values are printed raw", expr: ".created[].url", want: "url1\nurl2\n"},
+		{name: "numbers", expr: ".summary.total", want: "3\n"},
+		{name: "objects", expr: `{n: .requested[0].number}`, want: "{\"n\":3}\n"},
+		{name: "runtime error", expr: ".summary.total[]", wantErr: "failed to evaluate --jq expression"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := writeFilteredJSON(&buf, tt.expr, categories, records, nil)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestValidateJQ(t *testing.T) {
+	assert.NoError(t, validateJQ(".created | length"))
+	assert.Error(t, validateJQ(".created["))
+	assert.Error(t, validateJQ("undefined_function(1)"))
+}
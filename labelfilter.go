@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/v67/github"
+)
+
+// labelGroups collects the values of the repeatable --label flag, one group per flag
+type labelGroups [][]string
+
+func (g *labelGroups) String() string {
+	groups := make([]string, 0, len(*g))
+	for _, group := range *g {
+		groups = append(groups, strings.Join(group, ","))
+	}
+	return strings.Join(groups, " ")
+}
+
+func (g *labelGroups) Set(value string) error {
+	names, err := splitLabels(value)
+	if err != nil {
+		return err
+	}
+	*g = append(*g, names)
+	return nil
+}
+
+// labelList collects the values of the repeatable --exclude-label flag
+type labelList []string
+
+func (l *labelList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *labelList) Set(value string) error {
+	names, err := splitLabels(value)
+	if err != nil {
+		return err
+	}
+	*l = append(*l, names...)
+	return nil
+}
+
+// splitLabels splits a comma-separated list of label names
+func splitLabels(value string) ([]string, error) {
+	var names []string
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return nil, fmt.Errorf("label must not be empty")
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// matchesLabels reports whether a PR's labels pass the label filters. Every include
+// group must match at least one label, so groups are ANDed while the names within a
+// group are ORed, and no label may match an excluded name. Names are compared
+// case-insensitively.
+func matchesLabels(labels []*github.Label, include [][]string, exclude []string) bool {
+	has := make(map[string]bool, len(labels))
+	for _, label := range labels {
+		has[strings.ToLower(label.GetName())] = true
+	}
+	for _, name := range exclude {
+		if has[strings.ToLower(name)] {
+			return false
+		}
+	}
+	for _, group := range include {
+		if !hasAnyLabel(has, group) {
+			return false
+		}
+	}
+	return true
+}
+
+// hasAnyLabel reports whether any of names is among the lowercased labels in has
+func hasAnyLabel(has map[string]bool, names []string) bool {
+	for _, name := range names {
+		if has[strings.ToLower(name)] {
+			return true
+		}
+	}
+	return false
+}
+
+// filterByLabels keeps only the PRs whose labels pass the label filters
+func filterByLabels(issues []*github.Issue, include [][]string, exclude []string) []*github.Issue {
+	var filtered []*github.Issue
+	for _, issue := range issues {
+		if matchesLabels(issue.Labels, include, exclude) {
+			filtered = append(filtered, issue)
+		}
+	}
+	return filtered
+}
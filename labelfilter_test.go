@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-github/v67/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestPRWithLabels(title string, names ...string) *github.Issue {
+	pr := createTestPRInRepo(title, "owner/repo")
+	for _, name := range names {
+		pr.Labels = append(pr.Labels, createTestLabel(name, "ededed"))
+	}
+	return pr
+}
+
+func TestMatchesLabels(t *testing.T) {
+	tests := []struct {
+		name    string
+		labels  []string
+		include [][]string
+		exclude []string
+		want    bool
+	}{
+		{name: "no filters", labels: []string{"bug"}, want: true},
+		{name: "no filters without labels", want: true},
+		{name: "include matches", labels: []string{"priority", "bug"}, include: [][]string{{"priority"}}, want: true},
+		{name: "include ignores case", labels: []string{"Priority"}, include: [][]string{{"PRIORITY"}}, want: true},
+		{name: "include missing", labels: []string{"bug"}, include: [][]string{{"priority"}}, want: false},
+		{name: "include without labels", include: [][]string{{"priority"}}, want: false},
+		{name: "any label of a group", labels: []string{"urgent"}, include: [][]string{{"priority", "urgent"}}, want: true},
+		{name: "every group", labels: []string{"priority", "backend"}, include: [][]string{{"priority"}, {"backend"}}, want: true},
+		{name: "one group missing", labels: []string{"priority"}, include: [][]string{{"priority"}, {"backend"}}, want: false},
+		{name: "exclude matches", labels: []string{"bug", "wontfix"}, exclude: []string{"wontfix"}, want: false},
+		{name: "exclude ignores case", labels: []string{"WontFix"}, exclude: []string{"wontfix"}, want: false},
+		{name: "exclude missing", labels: []string{"bug"}, exclude: []string{"wontfix"}, want: true},
+		{
+			name:    "combined",
+			labels:  []string{"priority", "bug"},
+			include: [][]string{{"priority"}},
+			exclude: []string{"wontfix"},
+			want:    true,
+		},
+		{
+			name:    "exclude wins over include",
+			labels:  []string{"priority", "wontfix"},
+			include: [][]string{{"priority"}},
+			exclude: []string{"wontfix"},
+			want:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issue := createTestPRWithLabels("PR", tt.labels...)
+			assert.Equal(t, tt.want, matchesLabels(issue.Labels, tt.include, tt.exclude))
+		})
+	}
+}
+
+func TestLabelFlags(t *testing.T) {
+	var groups labelGroups
+	assert.NoError(t, groups.Set("priority"))
+	assert.NoError(t, groups.Set("backend, frontend"))
+	assert.Equal(t, labelGroups{{"priority"}, {"backend", "frontend"}}, groups)
+	assert.Equal(t, "priority backend,frontend", groups.String())
+	assert.Error(t, groups.Set(""))
+
+	var list labelList
+	assert.NoError(t, list.Set("wontfix"))
+	assert.NoError(t, list.Set("duplicate,invalid"))
+	assert.Equal(t, labelList{"wontfix", "duplicate", "invalid"}, list)
+	assert.Error(t, list.Set("wontfix,"))
+}
+
+func TestFilterIssuesByLabels(t *testing.T) {
+	issues := []*github.Issue{
+		createTestPRWithLabels("priority", "priority"),
+		createTestPRWithLabels("priority wontfix", "priority", "wontfix"),
+		createTestPRWithLabels("wontfix", "wontfix"),
+		createTestPRWithLabels("unlabeled"),
+	}
+
+	tests := []struct {
+		name string
+		opts Options
+		want []string
+	}{
+		{name: "no label filters", want: []string{"priority", "priority wontfix", "wontfix", "unlabeled"}},
+		{name: "include", opts: Options{IncludeLabels: [][]string{{"priority"}}}, want: []string{"priority", "priority wontfix"}},
+		{name: "exclude", opts: Options{ExcludeLabels: []string{"wontfix"}}, want: []string{"priority", "unlabeled"}},
+		{
+			name: "include and exclude",
+			opts: Options{IncludeLabels: [][]string{{"priority"}}, ExcludeLabels: []string{"wontfix"}},
+			want: []string{"priority"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, cat := range []string{categoryCreated, categoryReviewer} {
+				pc := &PRChecker{username: "testuser", opts: tt.opts}
+				got, err := pc.filterIssues(context.Background(), cat, issues)
+				assert.NoError(t, err)
+				var titles []string
+				for _, issue := range got {
+					titles = append(titles, issue.GetTitle())
+				}
+				assert.Equal(t, tt.want, titles, cat)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/google/go-github/v67/github"
+)
+
+// Label column
+const (
+	labelsLabel     = "Labels" // Header of the label column
+	maxLabelsLength = 24       // Width of the label column
+	labelSeparator  = ", "     // Separator between the labels of a PR
+)
+
+// ansiColor is a terminal color with the RGB value it is usually rendered as
+type ansiColor struct {
+	attr    color.Attribute
+	r, g, b int
+}
+
+// labelPalette holds the colors label colors are mapped to. Black is left out so dark
+// labels stay readable on dark terminals.
+var labelPalette = []ansiColor{
+	{color.FgRed, 205, 0, 0},
+	{color.FgGreen, 0, 205, 0},
+	{color.FgYellow, 205, 205, 0},
+	{color.FgBlue, 0, 0, 238},
+	{color.FgMagenta, 205, 0, 205},
+	{color.FgCyan, 0, 205, 205},
+	{color.FgWhite, 229, 229, 229},
+}
+
+// nearestANSIColor maps a GitHub label color such as "d73a4a" to the closest palette
+// color, reporting false when the value is not a hex color
+func nearestANSIColor(hex string) (color.Attribute, bool) {
+	hex = strings.TrimPrefix(hex, "#")
+	if len(hex) != 6 {
+		return 0, false
+	}
+	rgb, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return 0, false
+	}
+	r, g, b := int(rgb>>16), int(rgb>>8&0xff), int(rgb&0xff)
+
+	best, bestDistance := labelPalette[0].attr, -1
+	for _, c := range labelPalette {
+		distance := (r-c.r)*(r-c.r) + (g-c.g)*(g-c.g) + (b-c.b)*(b-c.b)
+		if bestDistance < 0 || distance < bestDistance {
+			best, bestDistance = c.attr, distance
+		}
+	}
+	return best, true
+}
+
+// labelText joins the names of a PR's labels, or returns "-" when it has none
+func labelText(labels []*github.Label) string {
+	if len(labels) == 0 {
+		return "-"
+	}
+	names := make([]string, 0, len(labels))
+	for _, label := range labels {
+		names = append(names, label.GetName())
+	}
+	return strings.Join(names, labelSeparator)
+}
+
+// writeLabels writes a PR's labels cell, each label in the color nearest to its GitHub
+// color. The list is truncated to the column width like any other cell.
+func writeLabels(w io.Writer, labels []*github.Label) {
+	text := labelText(labels)
+	fitted := truncateString(text, maxLabelsLength)
+	kept := len(text)
+	if !strings.HasPrefix(fitted, text) {
+		kept = len(strings.TrimSuffix(strings.TrimRight(fitted, " "), "..."))
+	}
+	if len(labels) == 0 {
+		fmt.Fprint(w, fitted)
+		return
+	}
+
+	pos := 0
+	for i, label := range labels {
+		if i > 0 {
+			pos = writeLabelPart(w, text, pos, len(labelSeparator), kept, nil)
+		}
+		var style *color.Color
+		if attr, ok := nearestANSIColor(label.GetColor()); ok {
+			style = color.New(attr)
+		}
+		pos = writeLabelPart(w, text, pos, len(label.GetName()), kept, style)
+	}
+	fmt.Fprint(w, fitted[kept:])
+}
+
+// writeLabelPart writes the next length bytes of text from pos, clipped to the kept
+// prefix, and returns the position after them
+func writeLabelPart(w io.Writer, text string, pos, length, kept int, style *color.Color) int {
+	end := min(pos+length, kept)
+	if pos < end {
+		if style != nil {
+			style.Fprint(w, text[pos:end])
+		} else {
+			fmt.Fprint(w, text[pos:end])
+		}
+	}
+	return pos + length
+}
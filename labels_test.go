@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/google/go-github/v67/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestLabel(name, hex string) *github.Label {
+	return &github.Label{Name: github.String(name), Color: github.String(hex)}
+}
+
+func TestNearestANSIColor(t *testing.T) {
+	tests := []struct {
+		hex    string
+		want   color.Attribute
+		wantOK bool
+	}{
+		{hex: "d73a4a", want: color.FgRed, wantOK: true},
+		{hex: "0e8a16", want: color.FgGreen, wantOK: true},
+		{hex: "fbca04", want: color.FgYellow, wantOK: true},
+		{hex: "0052cc", want: color.FgBlue, wantOK: true},
+		{hex: "cc33cc", want: color.FgMagenta, wantOK: true},
+		{hex: "0075ca", want: color.FgCyan, wantOK: true},
+		{hex: "ffffff", want: color.FgWhite, wantOK: true},
+		{hex: "#e4e669", want: color.FgYellow, wantOK: true},
+		{hex: ""},
+		{hex: "fff"},
+		{hex: "zzzzzz"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.hex, func(t *testing.T) {
+			got, ok := nearestANSIColor(tt.hex)
+			assert.Equal(t, tt.wantOK, ok)
+			if tt.wantOK {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestLabelText(t *testing.T) {
+	tests := []struct {
+		name   string
+		labels []*github.Label
+		want   string
+	}{
+		{name: "no labels", want: "-"},
+		{name: "single label", labels: []*github.Label{createTestLabel("bug", "d73a4a")}, want: "bug"},
+		{
+			name:   "several labels",
+			labels: []*github.Label{createTestLabel("bug", "d73a4a"), createTestLabel("good first issue", "7057ff")},
+			want:   "bug, good first issue",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, labelText(tt.labels))
+		})
+	}
+}
+
+func TestWriteLabels(t *testing.T) {
+	noColor := color.NoColor
+	defer func() { color.NoColor = noColor }()
+
+	labels := []*github.Label{createTestLabel("bug", "d73a4a"), createTestLabel("enhancement", "invalid")}
+	long := []*github.Label{createTestLabel("bug", "d73a4a"), createTestLabel("needs more investigation", "0e8a16")}
+
+	tests := []struct {
+		name    string
+		labels  []*github.Label
+		noColor bool
+		want    string
+	}{
+		{name: "no labels", noColor: true, want: "-                       "},
+		{name: "plain", labels: labels, noColor: true, want: "bug, enhancement        "},
+		{name: "truncated", labels: long, noColor: true, want: "bug, needs more inves..."},
+		{
+			name:   "colored",
+			labels: labels,
+			want:   "\x1b[31mbug\x1b[0m, enhancement        ",
+		},
+		{
+			name:   "colored and truncated",
+			labels: long,
+			want:   "\x1b[31mbug\x1b[0m, \x1b[32mneeds more inves\x1b[0m...",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			color.NoColor = tt.noColor
+			var buf bytes.Buffer
+			writeLabels(&buf, tt.labels)
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
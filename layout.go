@@ -0,0 +1,132 @@
+package main
+
+import (
+	"github.com/cli/go-gh/v2/pkg/term"
+)
+
+// Table layout limits
+const (
+	fallbackTerminalWidth = 80 // Width of the rule under the header when the output is not a terminal
+	fallbackTitleLength   = 33 // Title column when the output is not a terminal, as in the original fixed table
+	fallbackRepoLength    = 20 // Repository column when the output is not a terminal
+	fallbackAuthorLength  = 12 // Author column when the output is not a terminal
+	fallbackURLLength     = 48 // URL column when the output is not a terminal, enough for github.com/owner/repo/pull/N
+	minTitleLength        = 8  // Narrowest title column on small terminals
+	minRepoLength         = 6  // Narrowest repository column on small terminals
+	maxRepoLength         = 40 // Widest repository column, leaving the rest of wide terminals to titles
+	minAuthorLength       = 5  // Narrowest author column on small terminals
+	maxAuthorLength       = 24 // Widest author column
+	minURLLength          = 8  // Narrowest URL column, even when rows overflow the terminal
+)
+
+// Shares, in percent, of the width left after the fixed-size columns. The URL column
+// takes whatever the other columns leave.
+const (
+	titleShare  = 42
+	repoShare   = 25
+	authorShare = 19
+)
+
+// layout holds the widths of a table laid out for a terminal width
+type layout struct {
+	width  int // Total width of a row and of the rule under the header
+	title  int // Width of the title column
+	repo   int // Width of the "owner/name" repository column
+	author int // Width of the author column
+	url    int // Width of the URL column
+}
+
+// newLayout computes the column widths for a terminal width and column gap. extra is the
+// width taken by the other fixed-size columns shown, gaps included, and author reports
+// whether the author column is shown. The URL column takes what is left; when that is
+// below minURLLength the title gives up the difference first, and only once it is at
+// minTitleLength does the row overflow. When the width is unknown nothing has to fit, so
+// the fixed fallback widths are used and the URL column keeps room for whole links.
+func newLayout(width, gap, extra int, author bool) layout {
+	if width <= 0 {
+		l := layout{
+			width: fallbackTerminalWidth,
+			title: fallbackTitleLength,
+			repo:  fallbackRepoLength,
+			url:   fallbackURLLength,
+		}
+		if author {
+			l.author = fallbackAuthorLength
+		}
+		return l
+	}
+
+	gaps := 4 // Between the number, title, repository, time and URL columns
+	if author {
+		gaps++
+	}
+	flexible := width - maxNumberLength - maxUpdateLength - gaps*gap - extra
+	l := layout{
+		width: width,
+		title: max(minTitleLength, flexible*titleShare/100),
+		repo:  min(maxRepoLength, max(minRepoLength, flexible*repoShare/100)),
+	}
+	if author {
+		l.author = min(maxAuthorLength, max(minAuthorLength, flexible*authorShare/100))
+	}
+	l.url = flexible - l.title - l.repo - l.author
+	if l.url < minURLLength {
+		shrink := min(minURLLength-l.url, l.title-minTitleLength)
+		l.title -= shrink
+		l.url = max(minURLLength, l.url+shrink)
+	}
+	return l
+}
+
+// layout returns the column widths of a category's table for the checker's terminal
+// width, column gap and the columns the table shows
+func (pc *PRChecker) layout(category string) layout {
+	gap := len(pc.columnGap())
+	extra := pc.extraColumnsWidth(category)
+	if pc.opts.Compact && showsAuthor(category) {
+		l := newLayout(pc.width, gap, extra+compactAuthorLength+gap, false)
+		l.author = compactAuthorLength
+		return l
+	}
+	return newLayout(pc.width, gap, extra, showsAuthor(category))
+}
+
+// extraColumnsWidth returns the width of the optional fixed-size columns a category's
+// table shows, each with the gap before it
+func (pc *PRChecker) extraColumnsWidth(category string) int {
+	gap := len(pc.columnGap())
+	var width int
+	if pc.opts.Reviews {
+		width += maxReviewsLength + gap
+	}
+	if pc.opts.Checks {
+		width += maxChecksLength + gap
+	}
+	if pc.opts.Unresolved {
+		width += maxThreadsLength + gap
+	}
+	if pc.opts.Comments {
+		width += maxCommentsLength + gap
+	}
+	if pc.fetchesDiffStat(category) {
+		width += maxDiffStatLength + gap
+	}
+	if pc.opts.Labels {
+		width += maxLabelsLength + gap
+	}
+	return width
+}
+
+// terminalWidth returns the width of the terminal stdout is attached to, or 0 when
+// stdout is not a terminal or its size cannot be read
+func terminalWidth() int {
+	t := term.FromEnv()
+	if !t.IsTerminalOutput() {
+		return 0
+	}
+	width, _, err := t.Size()
+	if err != nil {
+		return 0
+	}
+	return width
+}
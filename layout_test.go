@@ -0,0 +1,162 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/google/go-github/v67/github"
+	"github.com/mattn/go-runewidth"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLayout(t *testing.T) {
+	tests := []struct {
+		name   string
+		width  int
+		gap    int
+		extra  int
+		author bool
+		want   layout
+	}{
+		{
+			name:  "unknown width falls back",
+			width: 0,
+			gap:   columnPadding,
+			want:  layout{width: 80, title: fallbackTitleLength, repo: fallbackRepoLength, url: fallbackURLLength},
+		},
+		{
+			name:   "unknown width with an author column",
+			width:  0,
+			gap:    columnPadding,
+			author: true,
+			want:   layout{width: 80, title: fallbackTitleLength, repo: fallbackRepoLength, author: fallbackAuthorLength, url: fallbackURLLength},
+		},
+		{
+			name:  "narrow terminal",
+			width: 60,
+			gap:   columnPadding,
+			want:  layout{width: 60, title: 12, repo: 7, url: 10},
+		},
+		{
+			name:   "narrow terminal shrinks the title to fit the URL",
+			width:  60,
+			gap:    columnPadding,
+			author: true,
+			want:   layout{width: 60, title: minTitleLength, repo: minRepoLength, author: minAuthorLength, url: minURLLength},
+		},
+		{
+			name:  "standard terminal",
+			width: 80,
+			gap:   columnPadding,
+			want:  layout{width: 80, title: 20, repo: 12, url: 17},
+		},
+		{
+			name:   "standard terminal with an author column",
+			width:  80,
+			gap:    columnPadding,
+			author: true,
+			want:   layout{width: 80, title: 19, repo: 11, author: 8, url: 9},
+		},
+		{
+			name:   "optional columns leave less for the others",
+			width:  80,
+			gap:    columnPadding,
+			extra:  maxChecksLength + columnPadding,
+			author: true,
+			want:   layout{width: 80, title: 15, repo: 9, author: 7, url: minURLLength},
+		},
+		{
+			name:   "wide terminal gives the surplus to titles",
+			width:  200,
+			gap:    columnPadding,
+			author: true,
+			want:   layout{width: 200, title: 70, repo: maxRepoLength, author: maxAuthorLength, url: 33},
+		},
+		{
+			name:   "wider gaps leave less for the columns",
+			width:  200,
+			gap:    8,
+			author: true,
+			want:   layout{width: 200, title: 57, repo: 34, author: maxAuthorLength, url: 22},
+		},
+		{
+			name:   "rows overflow once the title is at its minimum",
+			width:  40,
+			gap:    columnPadding,
+			author: true,
+			want:   layout{width: 40, title: minTitleLength, repo: minRepoLength, author: minAuthorLength, url: minURLLength},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, newLayout(tt.width, tt.gap, tt.extra, tt.author))
+		})
+	}
+}
+
+func TestURLWidthByTerminalWidth(t *testing.T) {
+	tests := []struct {
+		width        int
+		wantCreated  int
+		wantReviewer int
+	}{
+		{width: 0, wantCreated: fallbackURLLength, wantReviewer: fallbackURLLength},
+		{width: 60, wantCreated: 10, wantReviewer: minURLLength},
+		{width: 80, wantCreated: 17, wantReviewer: 9},
+		{width: 200, wantCreated: 59, wantReviewer: 33},
+	}
+
+	for _, tt := range tests {
+		pc := &PRChecker{width: tt.width}
+		assert.Equal(t, tt.wantCreated, pc.urlWidth(categoryCreated), "created at %d columns", tt.width)
+		assert.Equal(t, tt.wantReviewer, pc.urlWidth(categoryReviewer), "reviewer at %d columns", tt.width)
+	}
+}
+
+func TestFallbackLayoutFitsURLs(t *testing.T) {
+	const link = "https://github.com/owner/repo/pull/12345"
+	issue := &github.Issue{HTMLURL: github.String(link)}
+
+	for _, category := range []string{categoryCreated, categoryReviewer} {
+		pc := &PRChecker{opts: Options{TruncateURL: true}}
+		assert.Equal(t, fallbackTerminalWidth, pc.layout(category).width)
+		assert.GreaterOrEqual(t, pc.urlWidth(category), len(link), category)
+		assert.Equal(t, link, pc.formatURL(issue, category), category)
+	}
+}
+
+func TestTableRowsFitTerminalWidth(t *testing.T) {
+	now := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	pr := createTestPRInRepo("Add a much longer title that does not fit the title column", "some-organization/some-long-repository")
+	pr.Number = github.Int(12345)
+	pr.HTMLURL = github.String("https://github.com/some-organization/some-long-repository/pull/12345")
+	pr.User = &github.User{Login: github.String("a-contributor-with-a-long-login")}
+	pr.UpdatedAt = &github.Timestamp{Time: now.AddDate(0, 0, -3)}
+
+	noColor := color.NoColor
+	defer func() { color.NoColor = noColor }()
+	color.NoColor = true
+
+	for _, width := range []int{60, 80} {
+		for _, category := range []string{categoryCreated, categoryReviewer} {
+			var out bytes.Buffer
+			pc := &PRChecker{
+				username:  "testuser",
+				formatter: NewDisplayFormatter(),
+				out:       &out,
+				width:     width,
+				clock:     func() time.Time { return now },
+				opts:      Options{TruncateURL: true},
+			}
+			assert.NoError(t, pc.displayPullRequests([]*github.Issue{pr}, category))
+
+			for _, row := range strings.Split(out.String(), "\n") {
+				assert.LessOrEqual(t, runewidth.StringWidth(row), width, "%s at %d columns: %q", category, width, row)
+			}
+		}
+	}
+}
@@ -2,13 +2,27 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/cli/go-gh/v2/pkg/term"
 	"github.com/cli/go-gh/v2/pkg/text"
 	"github.com/fatih/color"
 	"github.com/google/go-github/v67/github"
@@ -17,28 +31,73 @@ import (
 
 // GitHub API configuration
 const (
-	githubAPIVersion   = "2022-11-28"
+	githubAPIVersion   = "2022-11-28" // Default for --api-version
+	headerAPIVersion   = "X-GitHub-Api-Version"
 	githubAcceptHeader = "application/vnd.github+json"
+
+	headerRequestID = "X-GitHub-Request-Id" // Identifies a request when reporting issues to GitHub support
 )
 
 // Pull request categories
 const (
-	categoryCreated  = "created"   // PRs created by the user
-	categoryReviewer = "requested" // PRs where user is requested as reviewer
+	categoryCreated   = "created"   // PRs created by the user
+	categoryReviewer  = "requested" // PRs where user is requested as reviewer
+	categoryAssigned  = "assigned"  // PRs assigned to the user
+	categoryMentioned = "mentioned" // PRs mentioning the user
+	categoryReReview  = "rereview"  // PRs the user reviewed that have new commits since
+)
+
+// Author column shown outside the created section
+const (
+	authorLabel   = "Author"
+	unknownAuthor = "unknown" // Shown for PRs whose author is missing, such as deleted accounts
+)
+
+// Draft PR marker
+const (
+	iconDraft  = "📝"     // Shown before the titles of draft PRs
+	draftLabel = "draft" // Label marking drafts when search results lack the draft flag
 )
 
 // Display configuration
 const (
-	maxTitleLength  = 33 // Maximum length for PR title display
+	maxNumberLength = 6  // Maximum length for the PR number column
 	maxUpdateLength = 17 // Maximum length for "updated at" timestamp
-	columnPadding   = 2  // Space between columns
-	displayWidth    = 80 // Total width of display
+	columnPadding   = 2  // Default space between columns
+)
+
+// defaultTimeout bounds resolving the username and collecting the results, each on its own
+const defaultTimeout = 10 * time.Second
+
+// Search configuration
+const (
+	maxSearchPerPage = 100 // Largest page the search API returns
+	maxSearchPages   = 10  // Pages fetched per search at most, the 1,000 results the API allows
 )
 
+// Time column formats selectable with --time-format
+const (
+	timeFormatRelative = "relative"
+	timeFormatAbsolute = "absolute"
+
+	absoluteTimeLayout = "2006-01-02 15:04" // Fits within maxUpdateLength
+
+	clockSkewTolerance = 5 * time.Minute // Future timestamps within this are treated as now
+)
+
+// defaultConcurrency is the number of categories fetched at once unless --concurrency is set
+const defaultConcurrency = 4
+
+// envNoColor disables styled output when set, following https://no-color.org
+const envNoColor = "NO_COLOR"
+
 // Status icons
 const (
-	iconCreated  = "🔨" // Icon for PRs created by user
-	iconReviewer = "👀" // Icon for PRs requiring review
+	iconCreated   = "🔨" // Icon for PRs created by user
+	iconReviewer  = "👀" // Icon for PRs requiring review
+	iconAssigned  = "📌" // Icon for PRs assigned to user
+	iconMentioned = "💭" // Icon for PRs mentioning user
+	iconReReview  = "🔁" // Icon for PRs to review again
 )
 
 // AsyncPRResult represents the result of an asynchronous PR fetch operation
@@ -53,13 +112,66 @@ type GitHubClient interface {
 	Get(ctx context.Context, path string, response interface{}) error
 }
 
-// githubRESTClient implements GitHubClient using REST API
+// githubRESTClient implements GitHubClient using REST API, with GraphQL for data REST
+// does not expose
 type githubRESTClient struct {
 	client *api.RESTClient
+	gql    *api.GraphQLClient
+
+	mu   sync.Mutex
+	rate *rateLimit // Rate limit reported by the most recent response
 }
 
 func (c *githubRESTClient) Get(ctx context.Context, path string, response interface{}) error {
-	return c.client.Get(path, response)
+	resp, err := c.client.RequestWithContext(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		var httpErr *api.HTTPError
+		if errors.As(err, &httpErr) {
+			c.recordHeaders(httpErr.Headers)
+			if id := httpErr.Headers.Get(headerRequestID); id != "" {
+				return &requestError{err: err, requestID: id}
+			}
+		}
+		return err
+	}
+	defer resp.Body.Close()
+	c.recordHeaders(resp.Header)
+
+	if resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(response)
+}
+
+// recordHeaders remembers the rate limit reported by a response
+func (c *githubRESTClient) recordHeaders(header http.Header) {
+	rate, ok := parseRateLimit(header)
+	if !ok {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.rate = rate
+}
+
+func (c *githubRESTClient) lastRateLimit() *rateLimit {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.rate
+}
+
+// requestError annotates a failed API request with the request ID GitHub assigned to it
+type requestError struct {
+	err       error
+	requestID string
+}
+
+func (e *requestError) Error() string {
+	return fmt.Sprintf("%s (request ID: %s)", e.err, e.requestID)
+}
+
+func (e *requestError) Unwrap() error {
+	return e.err
 }
 
 // PRChecker manages GitHub pull request operations and display
@@ -67,75 +179,305 @@ type PRChecker struct {
 	client    GitHubClient
 	username  string
 	formatter *DisplayFormatter
+	opts      Options
+	host      string                     // Host shown in section headers when several hosts are queried
+	timeout   time.Duration              // Deadline for resolving the username and for collecting; zero means none
+	out       io.Writer                  // Destination of the rendered results, os.Stdout when nil
+	width     int                        // Terminal width the table is laid out for, fallbackTerminalWidth when 0
+	diff      *resultDiff                // Changes since the previous refresh in watch mode
+	previous  map[string][]*github.Issue // Results of the previous refresh in watch mode
+	hidden    map[string]int             // PRs removed by client-side filters per category
+	counts    map[string]int             // Matching PRs per category, including any beyond --limit
+	pending   int                        // Review requests listed by the last run, for --fail-if-pending
+
+	scoreUrgency urgencyScorer    // Scoring used by --sort urgency, defaultUrgencyScore when nil
+	clock        func() time.Time // Current time for rendering, time.Now when nil
+	mergeDelay   time.Duration    // Wait between merge status fetches, mergeStatusRetryDelay when zero
+	opener       urlOpener        // Opens PRs for --open, the web browser when nil
+	clipboard    clipboardWriter  // Receives PR URLs for --copy, the system clipboard when nil
+	cache        resultCache      // Stores search results for --cache, files in the user cache directory when nil
+	progress     *progress        // Enrichment progress shown on stderr during a run
+	seen         *seenStore       // PRs already displayed, loaded for --new-only and --mark-all-seen
+	redact       *redactor        // Placeholder mapping for --redact, nil when output is not redacted
+
+	// Per-PR details fetched beyond the search results, keyed by the issue of each section
+	// so a PR listed in several sections keeps the details fetched for each of them
+	detailsMu sync.Mutex
+	details   map[*github.Issue]*prDetails
+
+	// Repositories owned or administered by the user, resolved lazily and kept once fetched
+	ownedMu sync.Mutex
+	owned   map[string]bool
 }
 
 // DisplayFormatter handles the formatting of PR information
 type DisplayFormatter struct {
-	headerStyle *color.Color
-	titleStyle  *color.Color
-	urlStyle    *color.Color
-	timeStyle   *color.Color
+	headerStyle   *color.Color
+	titleStyle    *color.Color
+	urlStyle      *color.Color
+	repoStyle     *color.Color
+	authorStyle   *color.Color
+	timeStyle     *color.Color
+	subtitleStyle *color.Color
+	groupStyle    *color.Color
+	staleStyle    *color.Color
 }
 
 // NewDisplayFormatter creates a DisplayFormatter with predefined styles
 func NewDisplayFormatter() *DisplayFormatter {
 	return &DisplayFormatter{
-		headerStyle: color.New(color.FgGreen, color.Bold),
-		titleStyle:  color.New(color.FgCyan),
-		urlStyle:    color.New(color.FgBlue, color.Underline),
-		timeStyle:   color.New(color.FgYellow),
+		headerStyle:   color.New(color.FgGreen, color.Bold),
+		titleStyle:    color.New(color.FgCyan),
+		urlStyle:      color.New(color.FgBlue, color.Underline),
+		repoStyle:     color.New(color.FgMagenta),
+		authorStyle:   color.New(color.FgHiBlack),
+		timeStyle:     color.New(color.FgYellow),
+		subtitleStyle: color.New(color.Faint),
+		groupStyle:    color.New(color.FgMagenta, color.Bold),
+		staleStyle:    color.New(color.FgRed),
 	}
 }
 
-// NewPRChecker initializes a new PRChecker instance
-func NewPRChecker() (*PRChecker, error) {
-	client, err := initializeGitHubClient()
+// NewPRChecker initializes a new PRChecker instance for the default host
+func NewPRChecker(opts Options) (*PRChecker, error) {
+	client, source, err := initializeGitHubClient(defaultHost(os.Getenv), opts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize GitHub client: %w", err)
 	}
-
-	username, err := fetchGitHubUsername(client)
+	pc, err := newPRChecker(opts, "", client, source, newRetryBudget(opts.MaxRetries))
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch GitHub username: %w", err)
+		return nil, err
 	}
+	if opts.Redact {
+		pc.redact = newRedactor()
+	}
+	return pc, nil
+}
+
+// newPRChecker creates a PRChecker around an authenticated client, retrying its failed
+// requests within budget, and resolves the username the client is authenticated as
+func newPRChecker(opts Options, host string, client GitHubClient, source string, budget *retryBudget) (*PRChecker, error) {
+	client = &retryingClient{client: client, budget: budget, attempts: opts.Retries, delay: retryBaseDelay}
 
-	return &PRChecker{
+	pc := &PRChecker{
 		client:    client,
-		username:  username,
 		formatter: NewDisplayFormatter(),
-	}, nil
+		opts:      opts,
+		host:      host,
+		timeout:   opts.Timeout,
+	}
+	pc.debugf("using auth token from %s", source)
+
+	username, err := fetchGitHubUsername(client, pc.timeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch GitHub username: %w", err)
+	}
+	pc.username = username
+
+	return pc, nil
+}
+
+// writer returns the destination of the rendered results
+func (pc *PRChecker) writer() io.Writer {
+	if pc.out == nil {
+		return os.Stdout
+	}
+	return pc.out
+}
+
+// now returns the current time used for rendering
+func (pc *PRChecker) now() time.Time {
+	if pc.clock == nil {
+		return time.Now()
+	}
+	return pc.clock()
+}
+
+// debugf prints a diagnostic message to stderr when verbose output is enabled
+func (pc *PRChecker) debugf(format string, args ...interface{}) {
+	if pc.opts.Verbose {
+		fmt.Fprintf(os.Stderr, "debug: "+format+"\n", args...)
+	}
 }
 
-// Run executes the main PR checking logic with concurrent requests
+// Run executes the main PR checking logic with concurrent requests, refreshing the results
+// until interrupted in watch mode
 func (pc *PRChecker) Run() error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	if pc.opts.PrintQuery {
+		return pc.printQueries(os.Stderr)
+	}
+	if !pc.opts.Watch {
+		return pc.runOnce(context.Background())
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	w := &watcher{interval: pc.opts.Interval, out: os.Stdout, errOut: os.Stderr, after: time.After}
+	return w.run(ctx, pc.runOnce)
+}
+
+// runOnce fetches and displays the results a single time. In watch mode the results are
+// compared with the previous refresh so changes are marked.
+func (pc *PRChecker) runOnce(ctx context.Context) error {
+	if err := pc.loadSeen(); err != nil {
+		return err
+	}
+	var sectionDone func(string, []*github.Issue) error
+	switch {
+	case pc.opts.MarkAllSeen:
+	case pc.opts.Stream:
+		sectionDone = pc.streamSection
+	case pc.opts.Format == formatNDJSON:
+		sectionDone = pc.ndjsonSection()
+	}
+	categories, results, err := pc.collectContext(ctx, sectionDone)
+	if err != nil {
+		return err
+	}
+	pc.countPending(results)
+	defer pc.warnRateLimit(os.Stderr)
+
+	if pc.opts.Watch {
+		if pc.previous != nil {
+			pc.diff = diffResults(pc.previous, results)
+		}
+		pc.previous = results
+	}
+
+	hostResults := []hostResult{{checker: pc, categories: categories, results: results}}
+	if pc.opts.MarkAllSeen {
+		return pc.markAllSeen(hostResults)
+	}
+	if sectionDone == nil {
+		if err := displayResults(pc.writer(), hostResults); err != nil {
+			return err
+		}
+	} else {
+		if pc.opts.Stream {
+			pc.printSummary(categories, results)
+		}
+		if rate := rateLimitOf(pc.client); pc.opts.ShowRateLimit && rate != nil {
+			fmt.Fprintln(os.Stderr, rate)
+		}
+	}
+	return pc.finishListed(hostResults)
+}
+
+// finishListed copies and opens the PRs listed for every host as requested, then records
+// them as seen
+func (pc *PRChecker) finishListed(hostResults []hostResult) error {
+	var urls []string
+	for _, hr := range hostResults {
+		urls = append(urls, listedURLs(hr.categories, hr.results)...)
+	}
+	if pc.opts.Copy {
+		if err := pc.copyListed(urls); err != nil {
+			return err
+		}
+	}
+	if pc.opts.Open {
+		if err := pc.openListed(urls); err != nil {
+			return err
+		}
+	}
+	return pc.recordSeen(resultMaps(hostResults)...)
+}
+
+// markAllSeen records the PRs of every host as seen without displaying them
+func (pc *PRChecker) markAllSeen(hostResults []hostResult) error {
+	maps := resultMaps(hostResults)
+	if err := pc.recordSeen(maps...); err != nil {
+		return err
+	}
+	total := 0
+	for _, results := range maps {
+		total += countResults(results)
+	}
+	fmt.Fprintf(pc.writer(), "Marked %d pull requests as seen\n", total)
+	return nil
+}
+
+// streamSection prints a category's table as soon as its results are ready
+func (pc *PRChecker) streamSection(category string, issues []*github.Issue) error {
+	pc.progress.clear()
+	return pc.displayPullRequests(issues, category)
+}
+
+// collect fetches, filters, enriches and orders the PRs of every category, returning the
+// categories in display order along with their results. When sectionDone is non-nil it is
+// called with each category's results as soon as they are ready, one call at a time, in
+// completion order.
+func (pc *PRChecker) collect(sectionDone func(string, []*github.Issue) error) ([]string, map[string][]*github.Issue, error) {
+	return pc.collectContext(context.Background(), sectionDone)
+}
+
+// collectContext is collect with a parent context, so watch mode can stop a refresh on
+// interrupt. The timeout applies to each call separately.
+func (pc *PRChecker) collectContext(ctx context.Context, sectionDone func(string, []*github.Issue) error) ([]string, map[string][]*github.Issue, error) {
+	ctx, cancel := withTimeout(ctx, pc.timeout)
 	defer cancel()
 
-	categories := []string{categoryCreated, categoryReviewer}
+	pc.progress = newProgress(os.Stderr, showsProgress(pc.opts))
+	defer pc.progress.clear()
+	pc.resetDetails()
+
+	categories := uniqueCategories(pc.opts.Categories)
 	errChan := make(chan error, len(categories))
+	sem := make(chan struct{}, pc.concurrency())
 	var wg sync.WaitGroup
 
 	resultMap := make(map[string][]*github.Issue)
+	hiddenMap := make(map[string]int)
+	countMap := make(map[string]int)
 	mapMutex := sync.Mutex{}
+	pc.hidden = hiddenMap // Only read under mapMutex until every category is done
+	pc.counts = countMap
 
 	for _, category := range categories {
 		wg.Add(1)
 		go func(cat string) {
 			defer wg.Done()
-			issues, err := pc.fetchPullRequests(ctx, cat)
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			result, enriched, err := pc.searchPullRequests(ctx, cat)
 			if err != nil {
 				errChan <- fmt.Errorf("error fetching %s PRs: %w", cat, err)
 				return
 			}
 
-			var issuesList []*github.Issue
-			if issues != nil {
-				issuesList = issues.Issues
+			issuesList := result.Issues
+			fetched := len(issuesList)
+			issuesList, err = pc.filterIssues(ctx, cat, issuesList)
+			if err != nil {
+				errChan <- fmt.Errorf("error filtering %s PRs: %w", cat, err)
+				return
+			}
+			if !enriched {
+				if err := pc.enrichIssues(ctx, cat, issuesList); err != nil {
+					errChan <- fmt.Errorf("error enriching %s PRs: %w", cat, err)
+					return
+				}
 			}
+			issuesList = pc.filterEnriched(cat, issuesList)
+			issuesList = pc.keepNew(issuesList)
+			pc.orderIssues(cat, issuesList, pc.now())
+			hidden := fetched - len(issuesList)
+			// The search total also covers results past the fetched pages, which were never
+			// filtered, so the count is not capped by --limit or the page limit
+			count := max(result.GetTotal(), fetched) - hidden
+			issuesList = pc.limitIssues(issuesList)
 
 			mapMutex.Lock()
+			defer mapMutex.Unlock()
 			resultMap[cat] = issuesList
-			mapMutex.Unlock()
+			hiddenMap[cat] = hidden
+			countMap[cat] = count
+			if sectionDone != nil {
+				if err := sectionDone(cat, issuesList); err != nil {
+					errChan <- err
+				}
+			}
 		}(category)
 	}
 
@@ -147,37 +489,186 @@ func (pc *PRChecker) Run() error {
 
 	select {
 	case err := <-errChan:
-		return err
+		return nil, nil, err
 	case <-ctx.Done():
-		return ctx.Err()
+		return nil, nil, ctx.Err()
 	case <-done:
-		for _, cat := range categories {
-			if err := pc.displayPullRequests(resultMap[cat], cat); err != nil {
+		pc.progress.clear()
+		switch {
+		case pc.opts.SortSections:
+			categories = sortSectionsByCount(categories, resultMap)
+		case len(pc.opts.SectionOrder) > 0:
+			categories = orderSections(categories, pc.opts.SectionOrder)
+		}
+		return categories, resultMap, nil
+	}
+}
+
+// concurrency returns the maximum number of categories fetched at once
+func (pc *PRChecker) concurrency() int {
+	if pc.opts.Concurrency > 0 {
+		return pc.opts.Concurrency
+	}
+	return defaultConcurrency
+}
+
+// uniqueCategories returns the categories to fetch with duplicates removed, keeping the
+// first occurrence of each. Every registered category is used when none are given.
+func uniqueCategories(categories []string) []string {
+	if len(categories) == 0 {
+		return defaultCategories()
+	}
+
+	seen := make(map[string]bool, len(categories))
+	var unique []string
+	for _, cat := range categories {
+		if !seen[cat] {
+			seen[cat] = true
+			unique = append(unique, cat)
+		}
+	}
+	return unique
+}
+
+// displayResults renders the fetched PRs of one or more hosts in the selected output
+// mode. Structured formats merge each category across hosts, while the table, HTML page,
+// Markdown and templates show one section per host and category.
+func displayResults(w io.Writer, hostResults []hostResult) error {
+	if len(hostResults) == 0 {
+		return nil
+	}
+	lead := hostResults[0].checker
+	opts := lead.opts
+	rates := hostRateLimits(hostResults)
+
+	if opts.JSON {
+		categories, records := mergeHostRecords(hostResults)
+		var extras map[string]interface{}
+		switch {
+		case len(hostResults) == 1 && rates[lead.host] != nil:
+			extras = map[string]interface{}{jsonRateLimitKey: rates[lead.host]}
+		case len(hostResults) > 1 && len(rates) > 0:
+			extras = map[string]interface{}{jsonRateLimitKey: rates}
+		}
+		return writeFilteredJSON(w, opts.JQ, categories, records, extras)
+	}
+
+	switch {
+	case opts.Template != "":
+		for _, hr := range hostResults {
+			if err := hr.checker.writeTemplate(w, hr.categories, hr.results); err != nil {
 				return err
 			}
 		}
-		return nil
+	case opts.HTML:
+		if err := writeHTML(w, hostResults); err != nil {
+			return err
+		}
+	case opts.Format == formatMarkdown:
+		for _, hr := range hostResults {
+			if err := hr.checker.writeMarkdown(w, hr.categories, hr.results); err != nil {
+				return err
+			}
+		}
+	case opts.Format == formatCSV:
+		categories, records := mergeHostRecords(hostResults)
+		if err := writeCSV(w, categories, records); err != nil {
+			return err
+		}
+	case opts.Format == formatYAML:
+		categories, records := mergeHostRecords(hostResults)
+		if err := writeYAML(w, categories, records); err != nil {
+			return err
+		}
+	case opts.Format == formatNDJSON:
+		categories, records := mergeHostRecords(hostResults)
+		if err := writeNDJSON(w, categories, records); err != nil {
+			return err
+		}
+	case opts.Format == formatTSV:
+		categories, records := mergeHostRecords(hostResults)
+		if err := writeTSV(w, categories, records); err != nil {
+			return err
+		}
+	default:
+		for _, hr := range hostResults {
+			if err := hr.checker.displayTable(hr.categories, hr.results); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, hr := range hostResults {
+		rate, ok := rates[hr.checker.host]
+		switch {
+		case !ok:
+		case len(hostResults) == 1:
+			fmt.Fprintln(os.Stderr, rate)
+		default:
+			fmt.Fprintf(os.Stderr, "%s: %s\n", hr.checker.host, rate)
+		}
 	}
+	return nil
+}
+
+// displayTable prints one terminal table section per category
+func (pc *PRChecker) displayTable(categories []string, results map[string][]*github.Issue) error {
+	for _, cat := range categories {
+		if err := pc.displayPullRequests(results[cat], cat); err != nil {
+			return err
+		}
+	}
+	pc.printSummary(categories, results)
+	return nil
 }
 
-func initializeGitHubClient() (GitHubClient, error) {
-	opts := api.ClientOptions{
-		Headers: map[string]string{
-			"Accept":               githubAcceptHeader,
-			"X-GitHub-Api-Version": githubAPIVersion,
-		},
+// initializeGitHubClient creates a REST client for host, or the default host when empty,
+// authenticated with the token chosen by resolveHostToken. The name of the token source
+// is returned alongside the client.
+func initializeGitHubClient(host string, opts Options) (GitHubClient, string, error) {
+	token, source := resolveHostToken(host, opts.Token)
+	if token == "" {
+		return nil, "", errNoToken
+	}
+
+	clientOpts := api.ClientOptions{
+		Host:      host,
+		AuthToken: token,
+		Headers:   requestHeaders(opts.APIVersion),
 	}
 
-	client, err := api.NewRESTClient(opts)
+	client, err := api.NewRESTClient(clientOpts)
 	if err != nil {
-		return nil, err
+		return nil, "", err
+	}
+	gql, err := api.NewGraphQLClient(clientOpts)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return &githubRESTClient{client: client, gql: gql}, source, nil
+}
+
+// requestHeaders returns the headers sent with every request, leaving out the API version when empty
+func requestHeaders(apiVersion string) map[string]string {
+	headers := map[string]string{"Accept": githubAcceptHeader}
+	if apiVersion != "" {
+		headers[headerAPIVersion] = apiVersion
 	}
+	return headers
+}
 
-	return &githubRESTClient{client: client}, nil
+// withTimeout returns a context that expires after timeout, or one that is only canceled
+// explicitly when timeout is zero
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout == 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
 }
 
-func fetchGitHubUsername(client GitHubClient) (string, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+func fetchGitHubUsername(client GitHubClient, timeout time.Duration) (string, error) {
+	ctx, cancel := withTimeout(context.Background(), timeout)
 	defer cancel()
 
 	var user github.User
@@ -191,98 +682,519 @@ func fetchGitHubUsername(client GitHubClient) (string, error) {
 	return *user.Login, nil
 }
 
-func (pc *PRChecker) fetchPullRequests(ctx context.Context, category string) (*github.IssuesSearchResult, error) {
-	query, err := pc.buildSearchQuery(category)
-	if err != nil {
-		return nil, err
+// fetchSearch fetches the PRs matching a category's search query over REST
+func (pc *PRChecker) fetchSearch(ctx context.Context, category, query string) (*github.IssuesSearchResult, error) {
+	perPage := pc.searchPageSize()
+	basePath := "search/issues?q=" + query + pc.searchSortParams() + fmt.Sprintf("&per_page=%d", perPage)
+	key := pc.resultCacheKey(basePath)
+	if result, ok := pc.cachedSearch(key); ok {
+		pc.debugf("using cached %s results", category)
+		return result, nil
+	}
+
+	// Follow pages until every result, or enough for --limit, is fetched
+	result := &github.IssuesSearchResult{}
+	for page := 1; page <= maxSearchPages; page++ {
+		path := basePath
+		if page > 1 {
+			path += fmt.Sprintf("&page=%d", page)
+		}
+		var response github.IssuesSearchResult
+		if err := pc.client.Get(ctx, path, &response); err != nil {
+			return nil, fmt.Errorf("failed to fetch pull requests: %w", err)
+		}
+		if page == 1 {
+			result.Total = response.Total
+		}
+		result.IncompleteResults = github.Bool(result.GetIncompleteResults() || response.GetIncompleteResults())
+		result.Issues = append(result.Issues, response.Issues...)
+
+		if len(response.Issues) < perPage || len(result.Issues) >= result.GetTotal() ||
+			(pc.opts.Limit > 0 && len(result.Issues) >= pc.opts.Limit) {
+			pc.storeSearch(key, result)
+			return result, nil
+		}
 	}
+	pc.debugf("%s search stopped after %d pages with %d of %d results", category, maxSearchPages, len(result.Issues), result.GetTotal())
+	pc.storeSearch(key, result)
+	return result, nil
+}
 
-	var response github.IssuesSearchResult
-	if err := pc.client.Get(ctx, "search/issues?q="+query, &response); err != nil {
-		return nil, fmt.Errorf("failed to fetch pull requests: %w", err)
+// searchPageSize returns the number of results requested per search page, no more than
+// --limit needs when it is set
+func (pc *PRChecker) searchPageSize() int {
+	if pc.opts.Limit > 0 {
+		return min(pc.opts.Limit, maxSearchPerPage)
 	}
+	return maxSearchPerPage
+}
 
-	return &response, nil
+// limitIssues caps a section at --limit PRs; a limit of zero or less keeps every PR
+func (pc *PRChecker) limitIssues(issues []*github.Issue) []*github.Issue {
+	if pc.opts.Limit > 0 && len(issues) > pc.opts.Limit {
+		return issues[:pc.opts.Limit]
+	}
+	return issues
 }
 
+// scopingQualifiers limit a search to a user, repository or organization; every query
+// must carry one so it never searches all of GitHub
+var scopingQualifiers = []string{"author", "user", "user-review-requested", "review-requested", "team-review-requested", "reviewed-by", "assignee", "mentions", "repo", "org"}
+
 func (pc *PRChecker) buildSearchQuery(category string) (string, error) {
+	c, err := lookupCategory(category)
+	if err != nil {
+		return "", err
+	}
+	return pc.buildQuery(category, c.Qualifiers(pc.username))
+}
+
+// buildQuery returns the search query for a category's PRs matching qualifiers, narrowed
+// by the filters that apply to every search
+func (pc *PRChecker) buildQuery(category, qualifiers string) (string, error) {
 	baseQuery := "is:open+is:pr+archived:false"
 
-	switch category {
-	case categoryCreated:
-		return fmt.Sprintf("%s+author:%s", baseQuery, pc.username), nil
-	case categoryReviewer:
-		return fmt.Sprintf("%s+user-review-requested:%s", baseQuery, pc.username), nil
-	default:
-		return "", fmt.Errorf("unsupported PR category: %s", category)
+	query := baseQuery + "+" + qualifiers
+	if category == categoryCreated && !pc.opts.Drafts {
+		// Review requests and mentions on drafts stay listed since they were sent deliberately
+		query += "+draft:false"
+	}
+	if pc.opts.Repo != "" {
+		if err := validateRepo(pc.opts.Repo); err != nil {
+			return "", err
+		}
+		query += "+repo:" + pc.opts.Repo
+	}
+	if pc.opts.Org != "" {
+		if err := validateOrg(pc.opts.Org); err != nil {
+			return "", err
+		}
+		query += "+org:" + pc.opts.Org
+	}
+	if pc.opts.Since.isSet() {
+		query += "+" + pc.opts.Since.qualifier(pc.now())
+	}
+	if pc.opts.QueryExtra != "" {
+		if err := validateQueryExtra(pc.opts.QueryExtra); err != nil {
+			return "", err
+		}
+		query += "+" + url.QueryEscape(strings.TrimSpace(pc.opts.QueryExtra))
+	}
+
+	if err := validateSearchQuery(query); err != nil {
+		return "", fmt.Errorf("invalid %s query: %w", category, err)
+	}
+	return query, nil
+}
+
+// validateRepo rejects repositories not in "owner/name" form
+func validateRepo(repo string) error {
+	owner, name, ok := strings.Cut(repo, "/")
+	if !ok || owner == "" || name == "" || strings.Contains(name, "/") {
+		return fmt.Errorf("invalid repository %q: must be owner/name", repo)
+	}
+	return nil
+}
+
+// orgNamePattern matches GitHub account names: up to 39 letters, digits and single
+// hyphens, neither starting nor ending with a hyphen
+var orgNamePattern = regexp.MustCompile(`^[A-Za-z0-9](?:-?[A-Za-z0-9]){0,38}$`)
+
+// validateOrg rejects names GitHub does not allow for organizations
+func validateOrg(org string) error {
+	if !orgNamePattern.MatchString(org) {
+		return fmt.Errorf("invalid organization %q: must be up to 39 letters, digits or single hyphens, not starting or ending with a hyphen", org)
+	}
+	return nil
+}
+
+// validateQueryExtra rejects --query-extra values that are blank or contain control
+// characters or invalid UTF-8, which cannot be typed into a search
+func validateQueryExtra(extra string) error {
+	if strings.TrimSpace(extra) == "" {
+		return fmt.Errorf("extra search qualifiers must not be blank")
+	}
+	if !utf8.ValidString(extra) {
+		return fmt.Errorf("extra search qualifiers %q are not valid UTF-8", extra)
+	}
+	if strings.ContainsFunc(extra, unicode.IsControl) {
+		return fmt.Errorf("extra search qualifiers %q must not contain control characters", extra)
+	}
+	return nil
+}
+
+// validateSearchQuery rejects "+"-separated queries that are empty or lack a scoping
+// qualifier with a value
+func validateSearchQuery(query string) error {
+	if strings.Trim(query, "+") == "" {
+		return fmt.Errorf("search query is empty")
+	}
+	for _, term := range strings.Split(query, "+") {
+		name, value, ok := strings.Cut(term, ":")
+		if ok && value != "" && slices.Contains(scopingQualifiers, name) {
+			return nil
+		}
 	}
+	return fmt.Errorf("search query %q has no %s qualifier", query, strings.Join(scopingQualifiers, ", "))
 }
 
 func (pc *PRChecker) displayPullRequests(issues []*github.Issue, category string) error {
+	w := pc.writer()
 	if err := pc.displaySectionHeader(category); err != nil {
 		return err
 	}
 
 	if len(issues) == 0 {
-		color.Yellow("No pull requests found\n\n")
+		color.New(color.FgYellow).Fprintf(w, "No pull requests found\n")
+		pc.displayRemoved(category)
+		fmt.Fprintln(w)
 		return nil
 	}
 
-	pc.displayTableHeader()
+	pc.displayTableHeader(category)
 
-	if err := pc.displayIssues(issues); err != nil {
+	display := pc.displayIssues
+	if pc.opts.GroupByRepo {
+		display = pc.displayGroupedIssues
+	}
+	if err := display(issues, category); err != nil {
 		return err
 	}
 
-	fmt.Println()
+	pc.displayRemoved(category)
+	fmt.Fprintln(w)
 	return nil
 }
 
 func (pc *PRChecker) displaySectionHeader(category string) error {
 	headerStyle := color.New(color.FgHiMagenta, color.Bold)
-	var icon, description string
-
-	switch category {
-	case categoryCreated:
-		icon, description = iconCreated, "Pull Requests Created by"
-	case categoryReviewer:
-		icon, description = iconReviewer, "Review Requests for"
-	default:
-		return fmt.Errorf("unsupported PR category: %s", category)
+	header, err := pc.sectionHeader(category)
+	if err != nil {
+		return err
 	}
 
-	headerStyle.Printf("\n%s %s %s\n\n", icon, description, pc.username)
+	headerStyle.Fprintf(pc.writer(), "\n%s\n\n", header)
 	return nil
 }
 
-func (pc *PRChecker) displayTableHeader() {
-	padding := strings.Repeat(" ", columnPadding)
+// sectionHeader returns the header text for a category's section
+func (pc *PRChecker) sectionHeader(category string) (string, error) {
+	icon, description, err := sectionTitle(category)
+	if err != nil {
+		return "", err
+	}
 
-	pc.formatter.headerStyle.Printf("Title%s", strings.Repeat(" ", maxTitleLength-len("Title")))
-	pc.formatter.headerStyle.Printf("%sUpdated%s", padding, strings.Repeat(" ", maxUpdateLength-len("Updated")))
-	pc.formatter.headerStyle.Printf("%sURL\n", padding)
-	fmt.Println(color.HiBlackString(strings.Repeat("-", displayWidth)))
+	header := fmt.Sprintf("%s %s %s", icon, description, pc.redact.user(pc.username))
+	if pc.host != "" {
+		header += " on " + pc.host
+	}
+	var notes []string
+	if count, ok := pc.counts[category]; ok {
+		notes = append(notes, strconv.Itoa(count))
+	}
+	if pc.opts.ShowHidden && pc.hidden[category] > 0 {
+		notes = append(notes, fmt.Sprintf("%d hidden by filters", pc.hidden[category]))
+	}
+	if len(notes) > 0 {
+		header += " (" + strings.Join(notes, ", ") + ")"
+	}
+	return header, nil
+}
+
+// sectionTitle returns the icon and description used in a category's section header
+func sectionTitle(category string) (string, string, error) {
+	c, err := lookupCategory(category)
+	if err != nil {
+		return "", "", err
+	}
+	return c.Icon, c.Description, nil
 }
 
-func (pc *PRChecker) displayIssues(issues []*github.Issue) error {
-	currentTime := time.Now()
-	padding := strings.Repeat(" ", columnPadding)
+func (pc *PRChecker) displayTableHeader(category string) {
+	w := pc.writer()
+	padding := pc.columnGap()
+	widths := pc.layout(category)
+
+	pc.formatter.headerStyle.Fprintf(w, "%s", alignCell("#", maxNumberLength, pc.alignment(columnNumber)))
+	pc.formatter.headerStyle.Fprintf(w, "%s%s", padding, alignCell("Title", widths.title, pc.alignment(columnTitle)))
+	pc.formatter.headerStyle.Fprintf(w, "%s%s", padding, alignCell("Repo", widths.repo, pc.alignment(columnRepo)))
+	if showsAuthor(category) {
+		pc.formatter.headerStyle.Fprintf(w, "%s%s", padding, alignCell(authorLabel, widths.author, pc.alignment(columnAuthor)))
+	}
+	timeLabel := timeFieldLabel(pc.opts.TimeField)
+	pc.formatter.headerStyle.Fprintf(w, "%s%s", padding, alignCell(timeLabel, maxUpdateLength, pc.alignment(columnTime)))
+	if pc.opts.Reviews {
+		pc.formatter.headerStyle.Fprintf(w, "%s%s", padding, alignCell(reviewsLabel, maxReviewsLength, pc.alignment(columnReviews)))
+	}
+	if pc.opts.Checks {
+		pc.formatter.headerStyle.Fprintf(w, "%s%s", padding, alignCell(checksLabel, maxChecksLength, pc.alignment(columnChecks)))
+	}
+	if pc.opts.Unresolved {
+		pc.formatter.headerStyle.Fprintf(w, "%s%s", padding, alignCell(threadsLabel, maxThreadsLength, pc.alignment(columnThreads)))
+	}
+	if pc.opts.Comments {
+		pc.formatter.headerStyle.Fprintf(w, "%s%s", padding, alignCell(commentsLabel, maxCommentsLength, pc.alignment(columnComments)))
+	}
+	if pc.fetchesDiffStat(category) {
+		pc.formatter.headerStyle.Fprintf(w, "%s%s", padding, alignCell(diffStatLabel, maxDiffStatLength, alignLeft))
+	}
+	if pc.opts.Labels {
+		pc.formatter.headerStyle.Fprintf(w, "%s%s", padding, alignCell(labelsLabel, maxLabelsLength, alignLeft))
+	}
+	pc.formatter.headerStyle.Fprintf(w, "%s%s\n", padding, pc.alignURL("URL", category))
+	fmt.Fprintln(w, color.HiBlackString(strings.Repeat("-", widths.width)))
+}
+
+func (pc *PRChecker) displayIssues(issues []*github.Issue, category string) error {
+	w := pc.writer()
+	currentTime := pc.now()
+	padding := pc.columnGap()
+	widths := pc.layout(category)
 
 	for _, issue := range issues {
 		if issue.Title == nil || issue.HTMLURL == nil {
 			return fmt.Errorf("received invalid issue data from GitHub")
 		}
 
-		title := truncateString(*issue.Title, maxTitleLength)
-		updated := truncateString(text.RelativeTimeAgo(currentTime, issue.UpdatedAt.Time), maxUpdateLength)
+		number := alignCell(pc.formatNumber(issue), maxNumberLength, pc.alignment(columnNumber))
+		title := alignCell(pc.formatTitle(issue, category), widths.title, pc.alignment(columnTitle))
+		repo := alignCell(pc.formatRepo(issue), widths.repo, pc.alignment(columnRepo))
+		updated := alignCell(pc.formatTime(issue, currentTime), maxUpdateLength, pc.alignment(columnTime))
+
+		pc.formatter.timeStyle.Fprintf(w, "%s", number)
+		pc.formatter.titleStyle.Fprintf(w, "%s%s", padding, title)
+		pc.formatter.repoStyle.Fprintf(w, "%s%s", padding, repo)
+		if showsAuthor(category) {
+			fmt.Fprint(w, padding)
+			pc.writeAuthor(w, issue, widths.author)
+		}
+		timeStyle := pc.formatter.timeStyle
+		if pc.isStaleIssue(issue, currentTime) {
+			timeStyle = pc.formatter.staleStyle
+		}
+		timeStyle.Fprintf(w, "%s%s", padding, updated)
+		if pc.opts.Reviews {
+			decision := pc.reviewDecisionOf(issue, category)
+			decision.style().Fprintf(w, "%s%s", padding, alignCell(decision.label(), maxReviewsLength, pc.alignment(columnReviews)))
+		}
+		if pc.opts.Checks {
+			fmt.Fprintf(w, "%s%s", padding, alignCell(pc.formatChecks(issue), maxChecksLength, pc.alignment(columnChecks)))
+		}
+		if pc.opts.Unresolved {
+			pc.formatter.timeStyle.Fprintf(w, "%s%s", padding, alignCell(pc.formatThreads(issue), maxThreadsLength, pc.alignment(columnThreads)))
+		}
+		if pc.opts.Comments {
+			pc.formatter.timeStyle.Fprintf(w, "%s%s", padding, alignCell(formatComments(issue), maxCommentsLength, pc.alignment(columnComments)))
+		}
+		if pc.fetchesDiffStat(category) {
+			fmt.Fprint(w, padding)
+			pc.writeDiffStat(w, issue)
+		}
+		if pc.opts.Labels {
+			fmt.Fprint(w, padding)
+			writeLabels(w, issue.Labels)
+		}
+		pc.formatter.urlStyle.Fprintf(w, "%s%s\n", padding, pc.alignURL(pc.formatURL(issue, category), category))
 
-		pc.formatter.titleStyle.Printf("%s", title)
-		pc.formatter.timeStyle.Printf("%s%s", padding, updated)
-		pc.formatter.urlStyle.Printf("%s%s\n", padding, *issue.HTMLURL)
+		if pc.opts.ShowBody {
+			if subtitle := bodySubtitle(issue.GetBody(), widths.width-len(padding)); subtitle != "" {
+				pc.formatter.subtitleStyle.Fprintf(w, "%s%s\n", padding, subtitle)
+			}
+		}
 	}
 	return nil
 }
 
+// bodySubtitle returns the first non-blank line of a PR body with leading Markdown heading,
+// quote and list markers removed, truncated to maxWidth. Empty bodies yield "".
+func bodySubtitle(body string, maxWidth int) string {
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimLeft(line, "#>*- \t")
+		line = strings.TrimSpace(sanitizeTitle(line))
+		if line == "" {
+			continue
+		}
+		if runewidth.StringWidth(line) > maxWidth {
+			line = strings.TrimRight(truncateString(line, maxWidth), " ")
+		}
+		return line
+	}
+	return ""
+}
+
+// formatTime returns the time shown in the time column for the selected time field, either
+// relative to now or as an absolute timestamp in the configured time zone
+func (pc *PRChecker) formatTime(issue *github.Issue, now time.Time) string {
+	t := issueTime(issue, pc.opts.TimeField)
+	if pc.opts.TimeFormat == timeFormatAbsolute {
+		return t.In(pc.location()).Format(absoluteTimeLayout)
+	}
+	return relativeTime(now, t)
+}
+
+// location returns the time zone absolute times are shown in
+func (pc *PRChecker) location() *time.Location {
+	if pc.opts.Location == nil {
+		return time.Local
+	}
+	return pc.opts.Location
+}
+
+// relativeTime describes t relative to now. Timestamps slightly ahead of the local clock,
+// as happens with clock skew between GitHub and the client, are shown as "just now";
+// anything further ahead is reported as being in the future.
+func relativeTime(now, t time.Time) string {
+	if t.After(now) {
+		if t.Sub(now) <= clockSkewTolerance {
+			return "just now"
+		}
+		return "in the future"
+	}
+	return text.RelativeTimeAgo(now, t)
+}
+
+// formatTitle returns the PR title prefixed with any applicable markers
+func (pc *PRChecker) formatTitle(issue *github.Issue, category string) string {
+	var markers []string
+	if marker := changeMarker(pc.diff.change(category, issue)); marker != "" {
+		markers = append(markers, marker)
+	}
+	if isDraft(issue) {
+		markers = append(markers, iconDraft)
+	}
+	if details := pc.detailsFor(issue); details != nil && updatedByOthers(details.lastActor, pc.username) {
+		markers = append(markers, iconOthersActivity)
+	}
+	if pc.isStaleIssue(issue, pc.now()) {
+		markers = append(markers, iconStale)
+	}
+	if pc.hasConflicts(issue) {
+		markers = append(markers, iconConflict)
+	}
+	return strings.Join(append(markers, sanitizeTitle(issue.GetTitle())), " ")
+}
+
+// isDraft reports whether a PR is a draft, going by the draft flag of search results and
+// falling back to a "draft" label for results that do not report it
+func isDraft(issue *github.Issue) bool {
+	if issue.Draft != nil {
+		return *issue.Draft
+	}
+	for _, label := range issue.Labels {
+		if strings.EqualFold(label.GetName(), draftLabel) {
+			return true
+		}
+	}
+	return false
+}
+
+// sanitizeTitle replaces control characters such as newlines and tabs with spaces so a
+// title always renders on a single table row
+func sanitizeTitle(title string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return ' '
+		}
+		return r
+	}, title)
+}
+
+// displayRemoved lists PRs that dropped out of a category since the previous refresh
+func (pc *PRChecker) displayRemoved(category string) {
+	for _, issue := range pc.diff.removedFrom(category) {
+		fmt.Fprintln(pc.writer(), color.HiBlackString("- %s (closed or no longer matching) %s", sanitizeTitle(issue.GetTitle()), pc.redact.url(issue.GetHTMLURL())))
+	}
+}
+
+// formatNumber returns the PR number shown in the number column, or an empty string when
+// the issue has no number
+func (pc *PRChecker) formatNumber(issue *github.Issue) string {
+	if issue.Number == nil {
+		return ""
+	}
+	return strconv.Itoa(pc.redact.number(repoFromIssue(issue), *issue.Number))
+}
+
+// formatRepo returns the "owner/name" shown in the repository column, or an empty string
+// when the issue's repository cannot be determined
+func (pc *PRChecker) formatRepo(issue *github.Issue) string {
+	return pc.redact.repo(repoFromIssue(issue))
+}
+
+// showsAuthor reports whether a category's table has an author column. It is left out
+// of the created section, where every PR is the user's own.
+func showsAuthor(category string) bool {
+	return category != categoryCreated
+}
+
+// formatAuthor returns the login shown in the author column, or "unknown" when the
+// search result has no user
+func (pc *PRChecker) formatAuthor(issue *github.Issue) string {
+	login := issue.GetUser().GetLogin()
+	if login == "" {
+		return unknownAuthor
+	}
+	return pc.redact.user(login)
+}
+
+// formatURL returns the link shown in a category's table, shortened to "owner/repo#123"
+// and truncated to fit the table width when requested. Structured outputs always use the full URL.
+func (pc *PRChecker) formatURL(issue *github.Issue, category string) string {
+	link := pc.redact.url(issue.GetHTMLURL())
+	if pc.opts.ShortURL {
+		if repo := repoFromIssue(issue); repo != "" && issue.Number != nil {
+			link = fmt.Sprintf("%s#%d", pc.redact.repo(repo), pc.redact.number(repo, *issue.Number))
+		}
+	}
+	width := pc.urlWidth(category)
+	if !pc.opts.TruncateURL || runewidth.StringWidth(link) <= width {
+		return link
+	}
+	return truncateString(link, width)
+}
+
+// repoFromIssue returns the "owner/name" of the repository an issue belongs to.
+// RepositoryURL is preferred, falling back to HTMLURL since search results may omit
+// the former; an empty string is returned when neither can be parsed.
+func repoFromIssue(issue *github.Issue) string {
+	if issue == nil {
+		return ""
+	}
+	if issue.RepositoryURL != nil {
+		// e.g. https://api.github.com/repos/owner/name or https://host/api/v3/repos/owner/name
+		segments := urlPathSegments(*issue.RepositoryURL)
+		for i := 0; i+2 < len(segments); i++ {
+			if segments[i] == "repos" {
+				return segments[i+1] + "/" + segments[i+2]
+			}
+		}
+	}
+	if issue.HTMLURL != nil {
+		// e.g. https://github.com/owner/name/pull/123
+		segments := urlPathSegments(*issue.HTMLURL)
+		if len(segments) >= 2 {
+			return segments[0] + "/" + segments[1]
+		}
+	}
+	return ""
+}
+
+// urlPathSegments splits the path of an absolute URL into its non-empty segments
+func urlPathSegments(rawURL string) []string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return nil
+	}
+
+	var segments []string
+	for _, segment := range strings.Split(u.Path, "/") {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+	return segments
+}
+
 func truncateString(s string, maxLength int) string {
 	width := runewidth.StringWidth(s)
 
@@ -306,13 +1218,60 @@ func truncateString(s string, maxLength int) string {
 	return result + strings.Repeat(" ", maxLength-resultWidth)
 }
 
+// colorDisabled reports whether styled output is turned off with --no-color or by setting
+// the NO_COLOR environment variable to any non-empty value
+func colorDisabled(noColor bool, getenv func(string) string) bool {
+	return noColor || getenv(envNoColor) != ""
+}
+
 func main() {
-	checker, err := NewPRChecker()
+	if len(os.Args) > 1 && os.Args[1] == commandURL {
+		err := runURLCommand(os.Args[2:])
+		if errors.Is(err, flag.ErrHelp) {
+			os.Exit(0)
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	cfg, err := loadDefaultConfig()
+	if err != nil {
+		log.Fatal(err)
+	}
+	opts, err := parseOptionsWithConfig(os.Args[1:], cfg)
+	if errors.Is(err, flag.ErrHelp) {
+		os.Exit(0)
+	}
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	if err := checker.Run(); err != nil {
+	// Decided before any DisplayFormatter is created, since their styles check it when
+	// printing. Files and pipes get plain text unless colors are forced.
+	color.NoColor = !useColor(opts.Color, opts.NoColor, opts.Output != "", term.FromEnv().IsTerminalOutput(), os.Getenv)
+
+	if opts.Prompt {
+		path, err := defaultPromptCachePath()
+		if err != nil {
+			log.Fatalf("failed to locate prompt cache: %v", err)
+		}
+		if err := runPrompt(opts, os.Stdout, path, fetchActionableCount); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	checkers, err := NewPRCheckers(opts)
+	if err != nil {
 		log.Fatal(err)
 	}
+
+	if err := runHosts(checkers); err != nil {
+		log.Fatal(err)
+	}
+	if code := exitCode(opts.FailIfPending, pendingReviews(checkers)); code != 0 {
+		os.Exit(code)
+	}
 }
@@ -1,19 +1,29 @@
 package main
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/fatih/color"
 	"github.com/google/go-github/v67/github"
 	"github.com/mattn/go-runewidth"
 	"github.com/stretchr/testify/assert"
 )
 
 type MockGitHubClient struct {
-	response interface{}
-	err      error
+	response  interface{}
+	responses map[string]interface{} // Responses keyed by request path, taking precedence over response
+	err       error
+
+	mu    sync.Mutex
+	paths []string // Requested paths in call order
 }
 
 func (m *MockGitHubClient) Get(ctx context.Context, path string, response interface{}) error {
@@ -21,6 +31,10 @@ func (m *MockGitHubClient) Get(ctx context.Context, path string, response interf
 		return fmt.Errorf("context is nil")
 	}
 
+	m.mu.Lock()
+	m.paths = append(m.paths, path)
+	m.mu.Unlock()
+
 	select {
 	case <-ctx.Done():
 		return ctx.Err()
@@ -31,6 +45,14 @@ func (m *MockGitHubClient) Get(ctx context.Context, path string, response interf
 		return m.err
 	}
 
+	if r, ok := m.responses[path]; ok {
+		b, err := json.Marshal(r)
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(b, response)
+	}
+
 	switch v := response.(type) {
 	case *github.User:
 		if r, ok := m.response.(*github.User); ok && r != nil {
@@ -44,15 +66,27 @@ func (m *MockGitHubClient) Get(ctx context.Context, path string, response interf
 		} else {
 			*v = github.IssuesSearchResult{Issues: []*github.Issue{}} // Return empty result on error
 		}
+	case *[]*github.Repository:
+		if r, ok := m.response.([]*github.Repository); ok {
+			*v = r
+		}
 	}
 	return nil
 }
 
+// requestedPaths returns the paths requested so far
+func (m *MockGitHubClient) requestedPaths() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]string(nil), m.paths...)
+}
+
 func createTestPR(title, url string) *github.Issue {
 	return &github.Issue{
-		Title:     github.String(title),
-		HTMLURL:   github.String(url),
-		UpdatedAt: &github.Timestamp{Time: time.Now()},
+		Title:            github.String(title),
+		HTMLURL:          github.String(url),
+		PullRequestLinks: &github.PullRequestLinks{HTMLURL: github.String(url)},
+		UpdatedAt:        &github.Timestamp{Time: time.Now()},
 	}
 }
 
@@ -65,6 +99,11 @@ func TestBuildSearchQuery(t *testing.T) {
 		name     string
 		category string
 		username string
+		repo     string
+		org      string
+		drafts   bool
+		since    string
+		extra    string
 		want     string
 		wantErr  bool
 	}{
@@ -72,6 +111,13 @@ func TestBuildSearchQuery(t *testing.T) {
 			name:     "created PRs query",
 			category: categoryCreated,
 			username: "testuser",
+			want:     "is:open+is:pr+archived:false+author:testuser+draft:false",
+		},
+		{
+			name:     "created PRs including drafts",
+			category: categoryCreated,
+			username: "testuser",
+			drafts:   true,
 			want:     "is:open+is:pr+archived:false+author:testuser",
 		},
 		{
@@ -80,17 +126,148 @@ func TestBuildSearchQuery(t *testing.T) {
 			username: "testuser",
 			want:     "is:open+is:pr+archived:false+user-review-requested:testuser",
 		},
+		{
+			name:     "assigned PRs query",
+			category: categoryAssigned,
+			username: "testuser",
+			want:     "is:open+is:pr+archived:false+assignee:testuser",
+		},
+		{
+			name:     "mentioned PRs query",
+			category: categoryMentioned,
+			username: "testuser",
+			want:     "is:open+is:pr+archived:false+mentions:testuser",
+		},
+		{
+			name:     "created PRs in one repository",
+			category: categoryCreated,
+			username: "testuser",
+			repo:     "owner/repo",
+			want:     "is:open+is:pr+archived:false+author:testuser+draft:false+repo:owner/repo",
+		},
+		{
+			name:     "review requests in one repository",
+			category: categoryReviewer,
+			username: "testuser",
+			repo:     "owner/repo",
+			want:     "is:open+is:pr+archived:false+user-review-requested:testuser+repo:owner/repo",
+		},
+		{
+			name:     "created PRs in one organization",
+			category: categoryCreated,
+			username: "testuser",
+			org:      "my-org",
+			want:     "is:open+is:pr+archived:false+author:testuser+draft:false+org:my-org",
+		},
+		{
+			name:     "organization and repository stack",
+			category: categoryMentioned,
+			username: "testuser",
+			repo:     "owner/repo",
+			org:      "my-org",
+			want:     "is:open+is:pr+archived:false+mentions:testuser+repo:owner/repo+org:my-org",
+		},
+		{
+			name:     "review requests updated within a day",
+			category: categoryReviewer,
+			username: "testuser",
+			since:    "24h",
+			want:     "is:open+is:pr+archived:false+user-review-requested:testuser+updated:%3E%3D2024-03-09T12:30:00Z",
+		},
+		{
+			name:     "created PRs updated since a date",
+			category: categoryCreated,
+			username: "testuser",
+			repo:     "owner/repo",
+			since:    "2024-01-01",
+			want:     "is:open+is:pr+archived:false+author:testuser+draft:false+repo:owner/repo+updated:%3E%3D2024-01-01",
+		},
+		{
+			name:     "created PRs with extra qualifiers",
+			category: categoryCreated,
+			username: "testuser",
+			extra:    "label:bug -author:someone",
+			want:     "is:open+is:pr+archived:false+author:testuser+draft:false+label%3Abug+-author%3Asomeone",
+		},
+		{
+			name:     "review requests with a quoted extra qualifier",
+			category: categoryReviewer,
+			username: "testuser",
+			extra:    `label:"needs review"`,
+			want:     "is:open+is:pr+archived:false+user-review-requested:testuser+label%3A%22needs+review%22",
+		},
+		{
+			name:     "assigned PRs with reserved characters",
+			category: categoryAssigned,
+			username: "testuser",
+			extra:    "in:title C++ & #1",
+			want:     "is:open+is:pr+archived:false+assignee:testuser+in%3Atitle+C%2B%2B+%26+%231",
+		},
+		{
+			name:     "mentioned PRs with surrounding spaces trimmed",
+			category: categoryMentioned,
+			username: "testuser",
+			since:    "2024-01-01",
+			extra:    "  language:go ",
+			want:     "is:open+is:pr+archived:false+mentions:testuser+updated:%3E%3D2024-01-01+language%3Ago",
+		},
+		{
+			name:     "extra qualifiers with a newline",
+			category: categoryCreated,
+			username: "testuser",
+			extra:    "label:bug\nrepo:other/repo",
+			wantErr:  true,
+		},
+		{
+			name:     "organization with invalid characters",
+			category: categoryCreated,
+			username: "testuser",
+			org:      "my_org",
+			wantErr:  true,
+		},
+		{
+			name:     "organization ending with a hyphen",
+			category: categoryCreated,
+			username: "testuser",
+			org:      "my-org-",
+			wantErr:  true,
+		},
+		{
+			name:     "repository without owner",
+			category: categoryCreated,
+			username: "testuser",
+			repo:     "repo",
+			wantErr:  true,
+		},
+		{
+			name:     "repository with extra slash",
+			category: categoryCreated,
+			username: "testuser",
+			repo:     "owner/repo/pulls",
+			wantErr:  true,
+		},
 		{
 			name:     "invalid category",
 			category: "invalid",
 			username: "testuser",
 			wantErr:  true,
 		},
+		{
+			name:     "missing username leaves the query unscoped",
+			category: categoryCreated,
+			username: "",
+			wantErr:  true,
+		},
 	}
 
+	now := time.Date(2024, 3, 10, 12, 30, 45, 0, time.UTC)
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			pc := &PRChecker{username: tt.username}
+			opts := Options{Repo: tt.repo, Org: tt.org, Drafts: tt.drafts, QueryExtra: tt.extra}
+			if tt.since != "" {
+				assert.NoError(t, opts.Since.Set(tt.since))
+			}
+			pc := &PRChecker{username: tt.username, opts: opts, clock: func() time.Time { return now }}
 			query, err := pc.buildSearchQuery(tt.category)
 
 			if tt.wantErr {
@@ -103,6 +280,35 @@ func TestBuildSearchQuery(t *testing.T) {
 	}
 }
 
+func TestValidateSearchQuery(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		wantErr bool
+	}{
+		{name: "author", query: "is:open+is:pr+author:testuser"},
+		{name: "review requested", query: "is:pr+user-review-requested:testuser"},
+		{name: "repository", query: "repo:owner/name"},
+		{name: "organization", query: "is:pr+org:example"},
+		{name: "empty", query: "", wantErr: true},
+		{name: "only separators", query: "++", wantErr: true},
+		{name: "stripped of scoping qualifiers", query: "is:open+is:pr+archived:false", wantErr: true},
+		{name: "qualifier without value", query: "is:pr+author:", wantErr: true},
+		{name: "scoping name as free text", query: "is:pr+author", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSearchQuery(tt.query)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
 func TestFetchGitHubUsername(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -135,7 +341,7 @@ func TestFetchGitHubUsername(t *testing.T) {
 				err:      tt.err,
 			}
 
-			got, err := fetchGitHubUsername(client)
+			got, err := fetchGitHubUsername(client, time.Second)
 			if tt.wantErr {
 				assert.Error(t, err)
 				return
@@ -191,7 +397,7 @@ func TestFetchPullRequests(t *testing.T) {
 			}
 
 			ctx := context.Background()
-			result, err := pc.fetchPullRequests(ctx, tt.category)
+			result, _, err := pc.searchPullRequests(ctx, tt.category)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -205,10 +411,105 @@ func TestFetchPullRequests(t *testing.T) {
 	}
 }
 
+func TestFetchPullRequestsLimit(t *testing.T) {
+	tests := []struct {
+		name     string
+		limit    int
+		wantPath string
+	}{
+		{name: "no limit", limit: 0, wantPath: "search/issues?q=is:open+is:pr+archived:false+author:testuser+draft:false&sort=updated&order=desc&per_page=100"},
+		{name: "negative limit", limit: -1, wantPath: "search/issues?q=is:open+is:pr+archived:false+author:testuser+draft:false&sort=updated&order=desc&per_page=100"},
+		{name: "limit", limit: 10, wantPath: "search/issues?q=is:open+is:pr+archived:false+author:testuser+draft:false&sort=updated&order=desc&per_page=10"},
+		{name: "limit above the page size", limit: 500, wantPath: "search/issues?q=is:open+is:pr+archived:false+author:testuser+draft:false&sort=updated&order=desc&per_page=100"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &MockGitHubClient{}
+			pc := &PRChecker{client: client, username: "testuser", opts: Options{Limit: tt.limit}}
+
+			_, _, err := pc.searchPullRequests(context.Background(), categoryCreated)
+			assert.NoError(t, err)
+			assert.Equal(t, []string{tt.wantPath}, client.requestedPaths())
+		})
+	}
+}
+
+// testSearchPage returns a search result page of count PRs numbered from first
+func testSearchPage(first, count, total int) *github.IssuesSearchResult {
+	page := &github.IssuesSearchResult{Total: github.Int(total)}
+	for n := first; n < first+count; n++ {
+		page.Issues = append(page.Issues, createTestPRWithNumber(n, fmt.Sprintf("url%d", n), time.Now()))
+	}
+	return page
+}
+
+func TestFetchPullRequestsPagination(t *testing.T) {
+	base := "search/issues?q=is:open+is:pr+archived:false+author:testuser+draft:false&sort=updated&order=desc&per_page=100"
+	client := &MockGitHubClient{responses: map[string]interface{}{
+		base:             testSearchPage(1, 100, 150),
+		base + "&page=2": testSearchPage(101, 50, 150),
+	}}
+	pc := &PRChecker{client: client, username: "testuser"}
+
+	result, _, err := pc.searchPullRequests(context.Background(), categoryCreated)
+	assert.NoError(t, err)
+	assert.Len(t, result.Issues, 150)
+	assert.Equal(t, 1, result.Issues[0].GetNumber())
+	assert.Equal(t, 150, result.Issues[149].GetNumber())
+	assert.Equal(t, 150, result.GetTotal())
+	assert.Equal(t, []string{base, base + "&page=2"}, client.requestedPaths())
+}
+
+func TestFetchPullRequestsPageCap(t *testing.T) {
+	// Every page is full and the total is never reached, so only the capped pages are fetched
+	client := &pagingClient{total: 5000}
+	pc := &PRChecker{client: client, username: "testuser"}
+
+	result, _, err := pc.searchPullRequests(context.Background(), categoryCreated)
+	assert.NoError(t, err)
+	assert.Len(t, result.Issues, maxSearchPages*maxSearchPerPage)
+	assert.Equal(t, maxSearchPages, client.pages)
+
+	// A limit stops paging once enough results are fetched
+	client = &pagingClient{total: 5000}
+	pc = &PRChecker{client: client, username: "testuser", opts: Options{Limit: 20}}
+	result, _, err = pc.searchPullRequests(context.Background(), categoryCreated)
+	assert.NoError(t, err)
+	assert.Len(t, result.Issues, 20)
+	assert.Equal(t, 1, client.pages)
+}
+
+// pagingClient returns full search pages for any path, counting the pages requested
+type pagingClient struct {
+	total int
+	pages int
+}
+
+func (c *pagingClient) Get(ctx context.Context, path string, response interface{}) error {
+	perPage := maxSearchPerPage
+	if _, value, ok := strings.Cut(path, "&per_page="); ok {
+		perPage, _ = strconv.Atoi(strings.SplitN(value, "&", 2)[0])
+	}
+	*response.(*github.IssuesSearchResult) = *testSearchPage(c.pages*perPage+1, perPage, c.total)
+	c.pages++
+	return nil
+}
+
+func TestLimitIssues(t *testing.T) {
+	issues := []*github.Issue{createTestPR("PR 1", "url1"), createTestPR("PR 2", "url2"), createTestPR("PR 3", "url3")}
+
+	assert.Equal(t, issues[:2], (&PRChecker{opts: Options{Limit: 2}}).limitIssues(issues))
+	assert.Equal(t, issues, (&PRChecker{opts: Options{Limit: 5}}).limitIssues(issues))
+	assert.Equal(t, issues, (&PRChecker{}).limitIssues(issues))
+	assert.Equal(t, issues, (&PRChecker{opts: Options{Limit: -1}}).limitIssues(issues))
+}
+
 func TestRun(t *testing.T) {
 	tests := []struct {
 		name    string
 		client  *MockGitHubClient
+		want    []string // Substrings of the rendered output
 		wantErr bool
 	}{
 		{
@@ -216,6 +517,12 @@ func TestRun(t *testing.T) {
 			client: &MockGitHubClient{
 				response: createTestPRList(createTestPR("Test PR", "url")),
 			},
+			want: []string{
+				iconCreated + " Pull Requests Created by testuser (1)",
+				iconReviewer + " Review Requests for testuser (1)",
+				"Test PR",
+				"Total: 1 created, 1 to review, 1 assigned, 1 mentioning you\n",
+			},
 		},
 		{
 			name: "api error",
@@ -228,22 +535,296 @@ func TestRun(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
 			pc := &PRChecker{
 				client:    tt.client,
 				username:  "testuser",
 				formatter: NewDisplayFormatter(),
+				out:       &out,
 			}
 
 			err := pc.Run()
 			if tt.wantErr {
 				assert.Error(t, err)
+				assert.Empty(t, out.String())
 				return
 			}
 			assert.NoError(t, err)
+			for _, want := range tt.want {
+				assert.Contains(t, out.String(), want)
+			}
 		})
 	}
 }
 
+func TestUniqueCategories(t *testing.T) {
+	assert.Equal(t, defaultCategories(), uniqueCategories(nil))
+	assert.Equal(t, []string{categoryReviewer, categoryCreated},
+		uniqueCategories([]string{categoryReviewer, categoryCreated, categoryReviewer, categoryCreated}))
+}
+
+func TestRunDuplicateCategories(t *testing.T) {
+	client := &MockGitHubClient{response: createTestPRList(createTestPR("Test PR", "url"))}
+	var out bytes.Buffer
+	pc := &PRChecker{
+		client:    client,
+		username:  "testuser",
+		formatter: NewDisplayFormatter(),
+		out:       &out,
+		opts:      Options{Categories: []string{categoryCreated, categoryCreated, categoryReviewer}},
+	}
+
+	assert.NoError(t, pc.Run())
+	assert.Equal(t, 1, strings.Count(out.String(), "Pull Requests Created by testuser"))
+	assert.Equal(t, 1, strings.Count(out.String(), "Review Requests for testuser"))
+
+	var searches []string
+	for _, path := range client.requestedPaths() {
+		if strings.HasPrefix(path, "search/issues") {
+			searches = append(searches, path)
+		}
+	}
+	assert.ElementsMatch(t, []string{
+		"search/issues?q=is:open+is:pr+archived:false+author:testuser+draft:false&sort=updated&order=desc&per_page=100",
+		"search/issues?q=is:open+is:pr+archived:false+user-review-requested:testuser&sort=updated&order=desc&per_page=100",
+	}, searches)
+}
+
+func TestCollectSelectedCategories(t *testing.T) {
+	client := &MockGitHubClient{response: createTestPRList(createTestPR("Test PR", "url"))}
+	pc := &PRChecker{client: client, username: "testuser", opts: Options{Categories: []string{categoryAssigned, categoryReviewer}}}
+
+	categories, results, err := pc.collect(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{categoryAssigned, categoryReviewer}, categories)
+	assert.NotContains(t, results, categoryCreated)
+
+	// Only the selected categories are searched
+	assert.ElementsMatch(t, []string{
+		"search/issues?q=is:open+is:pr+archived:false+assignee:testuser&sort=updated&order=desc&per_page=100",
+		"search/issues?q=is:open+is:pr+archived:false+user-review-requested:testuser&sort=updated&order=desc&per_page=100",
+	}, client.requestedPaths())
+}
+
+func TestCollectSharedPR(t *testing.T) {
+	pr := createTestPR("Shared PR", "https://github.com/owner/repo/pull/1")
+	client := &MockGitHubClient{responses: map[string]interface{}{
+		"search/issues?q=is:open+is:pr+archived:false+author:testuser+draft:false&sort=updated&order=desc&per_page=100": createTestPRList(pr),
+		"search/issues?q=is:open+is:pr+archived:false+mentions:testuser&sort=updated&order=desc&per_page=100":           createTestPRList(pr),
+	}}
+	pc := &PRChecker{client: client, username: "testuser", opts: Options{Categories: []string{categoryCreated, categoryMentioned}}}
+
+	_, results, err := pc.collect(nil)
+	assert.NoError(t, err)
+
+	// A PR matching several categories is listed in each of their sections
+	assert.Len(t, results[categoryCreated], 1)
+	assert.Len(t, results[categoryMentioned], 1)
+	assert.Equal(t, results[categoryCreated][0].GetHTMLURL(), results[categoryMentioned][0].GetHTMLURL())
+
+	// Details fetched for one section do not leak into the other
+	pc.setDetails(results[categoryCreated][0], &prDetails{lastActor: "someone"})
+	assert.Nil(t, pc.detailsFor(results[categoryMentioned][0]))
+}
+
+func TestCollectResetsDetails(t *testing.T) {
+	pr := createTestPRInRepo("Test PR", "owner/repo")
+	pr.Number = github.Int(1)
+	client := &MockGitHubClient{responses: map[string]interface{}{
+		"search/issues?q=is:open+is:pr+archived:false+author:testuser+draft:false&sort=updated&order=desc&per_page=100": createTestPRList(pr),
+		"repos/owner/repo/pulls/1": &github.PullRequest{Additions: github.Int(3), Deletions: github.Int(1)},
+	}}
+	pc := &PRChecker{client: client, username: "testuser", opts: Options{DiffStat: true, Categories: []string{categoryCreated}}}
+
+	// Each refresh decodes new issues, so the details of the previous one must not pile up
+	for range 2 {
+		_, results, err := pc.collect(nil)
+		assert.NoError(t, err)
+		assert.NotNil(t, pc.detailsFor(results[categoryCreated][0]))
+		assert.Len(t, pc.details, 1)
+	}
+}
+
+// concurrencyClient records the largest number of requests in flight at once
+type concurrencyClient struct {
+	mu       sync.Mutex
+	inFlight int
+	max      int
+}
+
+func (c *concurrencyClient) Get(ctx context.Context, path string, response interface{}) error {
+	c.mu.Lock()
+	c.inFlight++
+	c.max = max(c.max, c.inFlight)
+	c.mu.Unlock()
+
+	time.Sleep(20 * time.Millisecond)
+
+	c.mu.Lock()
+	c.inFlight--
+	c.mu.Unlock()
+	return nil
+}
+
+func TestCollectConcurrencyLimit(t *testing.T) {
+	for _, name := range []string{"extra-1", "extra-2", "extra-3"} {
+		withCategory(t, Category{Name: name, Qualifiers: func(username string) string { return "author:" + username }})
+	}
+
+	tests := []struct {
+		name        string
+		concurrency int
+		want        int
+	}{
+		{name: "one at a time", concurrency: 1, want: 1},
+		{name: "two at a time", concurrency: 2, want: 2},
+		{name: "default limit", want: defaultConcurrency},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &concurrencyClient{}
+			pc := &PRChecker{client: client, username: "testuser", opts: Options{Concurrency: tt.concurrency}}
+
+			categories, _, err := pc.collect(nil)
+			assert.NoError(t, err)
+			assert.Equal(t, defaultCategories(), categories)
+			assert.Equal(t, tt.want, client.max)
+		})
+	}
+}
+
+// latencyClient delays searches whose path contains a key of delays by its duration
+type latencyClient struct {
+	delays map[string]time.Duration
+}
+
+func (c *latencyClient) Get(ctx context.Context, path string, response interface{}) error {
+	for key, delay := range c.delays {
+		if strings.Contains(path, key) {
+			time.Sleep(delay)
+		}
+	}
+	return nil
+}
+
+func TestCollectSectionOrder(t *testing.T) {
+	// Review requests finish last and mentions first, yet sections follow --order-sections
+	client := &latencyClient{delays: map[string]time.Duration{
+		"user-review-requested:": 60 * time.Millisecond,
+		"author:":                30 * time.Millisecond,
+	}}
+	pc := &PRChecker{client: client, username: "testuser", opts: Options{
+		SectionOrder: []string{categoryReviewer, categoryCreated},
+	}}
+
+	categories, _, err := pc.collect(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{categoryReviewer, categoryCreated, categoryAssigned, categoryMentioned}, categories)
+}
+
+// blockingClient answers requests only once their context is done
+type blockingClient struct{}
+
+func (c *blockingClient) Get(ctx context.Context, path string, response interface{}) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestTimeout(t *testing.T) {
+	_, err := fetchGitHubUsername(&blockingClient{}, 10*time.Millisecond)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	pc := &PRChecker{client: &blockingClient{}, username: "testuser", timeout: 10 * time.Millisecond}
+	start := time.Now()
+	_, _, err = pc.collect(nil)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+
+	// Without a timeout the context is never done on its own
+	ctx, cancel := withTimeout(context.Background(), 0)
+	_, ok := ctx.Deadline()
+	assert.False(t, ok)
+	cancel()
+	assert.ErrorIs(t, ctx.Err(), context.Canceled)
+}
+
+func TestCollectStream(t *testing.T) {
+	client := &latencyClient{delays: map[string]time.Duration{"author:": 100 * time.Millisecond}}
+	pc := &PRChecker{client: client, username: "testuser", opts: Options{Categories: []string{categoryCreated, categoryReviewer}}}
+
+	// Streaming reports each section as it completes, so the slow created search comes last
+	var streamed []string
+	categories, _, err := pc.collect(func(category string, issues []*github.Issue) error {
+		streamed = append(streamed, category)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{categoryReviewer, categoryCreated}, streamed)
+	assert.Equal(t, []string{categoryCreated, categoryReviewer}, categories)
+
+	// Buffered collection keeps the fixed order regardless of latency
+	categories, _, err = pc.collect(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{categoryCreated, categoryReviewer}, categories)
+
+	// A failing callback stops the run
+	_, _, err = pc.collect(func(string, []*github.Issue) error { return fmt.Errorf("write failed") })
+	assert.EqualError(t, err, "write failed")
+}
+
+func TestRunHiddenCounts(t *testing.T) {
+	own := createTestPR("Own PR", "url1")
+	own.User = &github.User{Login: github.String("testuser")}
+	other := createTestPR("Other PR", "url2")
+	other.User = &github.User{Login: github.String("someone")}
+
+	var out bytes.Buffer
+	pc := &PRChecker{
+		client:    &MockGitHubClient{response: createTestPRList(own, other)},
+		username:  "testuser",
+		formatter: NewDisplayFormatter(),
+		out:       &out,
+		opts:      Options{ShowHidden: true, Categories: []string{categoryCreated, categoryReviewer}},
+	}
+
+	assert.NoError(t, pc.Run())
+	assert.Contains(t, out.String(), "Review Requests for testuser (1, 1 hidden by filters)")
+	assert.Equal(t, map[string]int{categoryCreated: 0, categoryReviewer: 1}, pc.hidden)
+
+	header, err := pc.sectionHeader(categoryReviewer)
+	assert.NoError(t, err)
+	assert.Equal(t, "👀 Review Requests for testuser (1, 1 hidden by filters)", header)
+
+	header, err = pc.sectionHeader(categoryCreated)
+	assert.NoError(t, err)
+	assert.Equal(t, "🔨 Pull Requests Created by testuser (2)", header)
+
+	pc.opts.ShowHidden = false
+	header, err = pc.sectionHeader(categoryReviewer)
+	assert.NoError(t, err)
+	assert.Equal(t, "👀 Review Requests for testuser (1)", header)
+}
+
+func TestSectionHeaderCount(t *testing.T) {
+	response := createTestPRList(createTestPR("PR 1", "url1"), createTestPR("PR 2", "url2"), createTestPR("PR 3", "url3"))
+	response.Total = github.Int(12)
+	pc := &PRChecker{
+		client:   &MockGitHubClient{response: response},
+		username: "testuser",
+		opts:     Options{Limit: 2, Categories: []string{categoryCreated}},
+	}
+
+	_, results, err := pc.collect(nil)
+	assert.NoError(t, err)
+	assert.Len(t, results[categoryCreated], 2)
+
+	// The header counts every match the search reported, not just the PRs shown
+	header, err := pc.sectionHeader(categoryCreated)
+	assert.NoError(t, err)
+	assert.Equal(t, "🔨 Pull Requests Created by testuser (12)", header)
+}
+
 func TestTruncateString(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -292,17 +873,386 @@ func TestTruncateString(t *testing.T) {
 	}
 }
 
+func TestRepoFromIssue(t *testing.T) {
+	tests := []struct {
+		name  string
+		issue *github.Issue
+		want  string
+	}{
+		{
+			name: "repository url only",
+			issue: &github.Issue{
+				RepositoryURL: github.String("https://api.github.com/repos/owner/name"),
+			},
+			want: "owner/name",
+		},
+		{
+			name: "enterprise repository url",
+			issue: &github.Issue{
+				RepositoryURL: github.String("https://ghe.example.com/api/v3/repos/owner/name"),
+			},
+			want: "owner/name",
+		},
+		{
+			name: "html url only",
+			issue: &github.Issue{
+				HTMLURL: github.String("https://github.com/owner/name/pull/123"),
+			},
+			want: "owner/name",
+		},
+		{
+			name: "both prefer repository url",
+			issue: &github.Issue{
+				RepositoryURL: github.String("https://api.github.com/repos/owner/name"),
+				HTMLURL:       github.String("https://github.com/other/repo/pull/1"),
+			},
+			want: "owner/name",
+		},
+		{
+			name: "malformed repository url falls back to html url",
+			issue: &github.Issue{
+				RepositoryURL: github.String("not a url"),
+				HTMLURL:       github.String("https://github.com/owner/name/pull/123"),
+			},
+			want: "owner/name",
+		},
+		{
+			name:  "neither url",
+			issue: &github.Issue{Title: github.String("Test PR")},
+			want:  "",
+		},
+		{
+			name:  "unparsable html url",
+			issue: &github.Issue{HTMLURL: github.String("url")},
+			want:  "",
+		},
+		{
+			name:  "nil issue",
+			issue: nil,
+			want:  "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, repoFromIssue(tt.issue))
+		})
+	}
+}
+
+func TestSanitizeTitle(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "plain title", input: "fix: resolve bug", want: "fix: resolve bug"},
+		{name: "embedded newlines", input: "fix: bug\r\nsecond line\n", want: "fix: bug  second line "},
+		{name: "tabs and escape", input: "a\tb\x1bc", want: "a b c"},
+		{name: "unicode kept", input: "修正\nする", want: "修正 する"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, sanitizeTitle(tt.input))
+		})
+	}
+}
+
+func TestBodySubtitle(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		maxWidth int
+		want     string
+	}{
+		{name: "empty body", body: "", maxWidth: 20, want: ""},
+		{name: "blank lines only", body: "\n  \r\n", maxWidth: 20, want: ""},
+		{name: "first line", body: "Fixes the login bug\n\nMore details", maxWidth: 40, want: "Fixes the login bug"},
+		{name: "heading marker", body: "## Summary\nbody", maxWidth: 40, want: "Summary"},
+		{name: "quote and list markers", body: "\n> - quoted item", maxWidth: 40, want: "quoted item"},
+		{name: "carriage return", body: "Windows line\r\nnext", maxWidth: 40, want: "Windows line"},
+		{name: "truncated", body: "This description is far too long", maxWidth: 15, want: "This descrip..."},
+		{name: "wide characters", body: "日本語の説明文です", maxWidth: 10, want: "日本語..."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := bodySubtitle(tt.body, tt.maxWidth)
+			assert.Equal(t, tt.want, got)
+			assert.LessOrEqual(t, runewidth.StringWidth(got), tt.maxWidth)
+		})
+	}
+}
+
+func TestFormatTitleMultiline(t *testing.T) {
+	pc := &PRChecker{width: 110}
+	issue := createTestPR("first line\nsecond line\nthird line that is quite long", "url")
+
+	title := truncateString(pc.formatTitle(issue, categoryCreated), pc.layout(categoryCreated).title)
+	assert.NotContains(t, title, "\n")
+	assert.Equal(t, pc.layout(categoryCreated).title, runewidth.StringWidth(title))
+	assert.Equal(t, "first line second line third l...", title)
+}
+
+func TestFormatTitleDraft(t *testing.T) {
+	tests := []struct {
+		name  string
+		issue func(*github.Issue)
+		want  string
+	}{
+		{
+			name:  "draft flag",
+			issue: func(i *github.Issue) { i.Draft = github.Bool(true) },
+			want:  iconDraft + " Add feature",
+		},
+		{
+			name:  "ready for review",
+			issue: func(i *github.Issue) { i.Draft = github.Bool(false) },
+			want:  "Add feature",
+		},
+		{
+			name:  "draft label without the flag",
+			issue: func(i *github.Issue) { i.Labels = []*github.Label{{Name: github.String("Draft")}} },
+			want:  iconDraft + " Add feature",
+		},
+		{
+			name:  "no draft information",
+			issue: func(*github.Issue) {},
+			want:  "Add feature",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issue := createTestPR("Add feature", "url")
+			tt.issue(issue)
+			pc := &PRChecker{}
+			assert.Equal(t, tt.want, pc.formatTitle(issue, categoryCreated))
+		})
+	}
+}
+
+func TestFormatTime(t *testing.T) {
+	now := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	issue := &github.Issue{
+		CreatedAt: &github.Timestamp{Time: now.AddDate(0, 0, -7)},
+		UpdatedAt: &github.Timestamp{Time: now.Add(-2 * time.Hour)},
+	}
+
+	tests := []struct {
+		name      string
+		timeField string
+		want      string
+	}{
+		{name: "default uses updated", timeField: "", want: "about 2 hours ago"},
+		{name: "updated", timeField: timeFieldUpdated, want: "about 2 hours ago"},
+		{name: "created", timeField: timeFieldCreated, want: "about 7 days ago"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pc := &PRChecker{opts: Options{TimeField: tt.timeField}}
+			assert.Equal(t, tt.want, pc.formatTime(issue, now))
+		})
+	}
+}
+
+func TestRelativeTime(t *testing.T) {
+	now := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		t    time.Time
+		want string
+	}{
+		{name: "past", t: now.Add(-2 * time.Hour), want: "about 2 hours ago"},
+		{name: "exactly now", t: now, want: "less than a minute ago"},
+		{name: "seconds in the future", t: now.Add(3 * time.Second), want: "just now"},
+		{name: "at the skew tolerance", t: now.Add(clockSkewTolerance), want: "just now"},
+		{name: "far in the future", t: now.Add(2 * time.Hour), want: "in the future"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, relativeTime(now, tt.t))
+		})
+	}
+}
+
+func TestFormatTimeAbsolute(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	assert.NoError(t, err)
+	newYork, err := time.LoadLocation("America/New_York")
+	assert.NoError(t, err)
+
+	instant := time.Date(2024, 1, 1, 15, 30, 0, 0, time.UTC)
+	issue := &github.Issue{UpdatedAt: &github.Timestamp{Time: instant}}
+
+	tests := []struct {
+		name     string
+		location *time.Location
+		want     string
+	}{
+		{name: "utc", location: time.UTC, want: "2024-01-01 15:30"},
+		{name: "tokyo crosses midnight", location: tokyo, want: "2024-01-02 00:30"},
+		{name: "new york", location: newYork, want: "2024-01-01 10:30"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pc := &PRChecker{opts: Options{TimeFormat: timeFormatAbsolute, Location: tt.location}}
+			got := pc.formatTime(issue, instant.Add(time.Hour))
+			assert.Equal(t, tt.want, got)
+			assert.LessOrEqual(t, len(got), maxUpdateLength)
+		})
+	}
+
+	// Relative times ignore the time zone
+	pc := &PRChecker{opts: Options{TimeFormat: timeFormatRelative, Location: tokyo}}
+	assert.Equal(t, "about 1 hour ago", pc.formatTime(issue, instant.Add(time.Hour)))
+}
+
+func TestFormatURL(t *testing.T) {
+	longURL := "https://github.com/some-very-long-organization-name/some-very-long-repository-name/pull/12345"
+	longPR := createTestPR("PR", longURL)
+	longPR.Number = github.Int(12345)
+
+	shortPR := createTestPRInRepo("PR", "owner/repo")
+	shortPR.Number = github.Int(123)
+
+	tests := []struct {
+		name        string
+		issue       *github.Issue
+		truncateURL bool
+		shortURL    bool
+		want        string
+	}{
+		{
+			name:  "long url without truncation",
+			issue: longPR,
+			want:  longURL,
+		},
+		{
+			name:        "short url with truncation",
+			issue:       createTestPR("PR", "https://github.com/a/b/1"),
+			truncateURL: true,
+			want:        "https://github.com/a/b/1",
+		},
+		{
+			name:        "long url with truncation",
+			issue:       longPR,
+			truncateURL: true,
+			want:        "https://github.com/some-...",
+		},
+		{
+			name:     "shortened to repo and number",
+			issue:    shortPR,
+			shortURL: true,
+			want:     "owner/repo#123",
+		},
+		{
+			name:        "shortened long url still truncated",
+			issue:       longPR,
+			shortURL:    true,
+			truncateURL: true,
+			want:        "some-very-long-organizat...",
+		},
+		{
+			name:     "missing number keeps full url",
+			issue:    createTestPRInRepo("PR", "owner/repo"),
+			shortURL: true,
+			want:     "https://github.com/owner/repo/pull/1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pc := &PRChecker{width: 110, opts: Options{TruncateURL: tt.truncateURL, ShortURL: tt.shortURL}}
+			got := pc.formatURL(tt.issue, categoryCreated)
+			assert.Equal(t, tt.want, got)
+			if tt.truncateURL {
+				widths := pc.layout(categoryCreated)
+				rowWidth := maxNumberLength + widths.title + widths.repo + maxUpdateLength + 4*columnPadding + runewidth.StringWidth(got)
+				assert.LessOrEqual(t, rowWidth, widths.width)
+			}
+		})
+	}
+}
+
+func TestFormatAuthor(t *testing.T) {
+	tests := []struct {
+		name   string
+		user   *github.User
+		redact bool
+		want   string
+	}{
+		{name: "author login", user: &github.User{Login: github.String("octocat")}, want: "octocat"},
+		{name: "missing user", want: unknownAuthor},
+		{name: "user without login", user: &github.User{}, want: unknownAuthor},
+		{name: "redacted login", user: &github.User{Login: github.String("octocat")}, redact: true, want: "user-a"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issue := createTestPR("PR", "url")
+			issue.User = tt.user
+			pc := &PRChecker{}
+			if tt.redact {
+				pc.redact = newRedactor()
+			}
+			assert.Equal(t, tt.want, pc.formatAuthor(issue))
+		})
+	}
+
+	assert.False(t, showsAuthor(categoryCreated))
+	for _, cat := range []string{categoryReviewer, categoryAssigned, categoryMentioned} {
+		assert.True(t, showsAuthor(cat))
+	}
+}
+
+func TestFormatNumber(t *testing.T) {
+	issue := createTestPRInRepo("PR", "owner/repo")
+	issue.Number = github.Int(42)
+
+	pc := &PRChecker{}
+	assert.Equal(t, "42", pc.formatNumber(issue))
+	assert.Equal(t, "", pc.formatNumber(createTestPR("PR", "url")))
+
+	// Numbers are right-aligned so their last digits line up
+	assert.Equal(t, "    42", alignCell(pc.formatNumber(issue), maxNumberLength, pc.alignment(columnNumber)))
+
+	pc.redact = newRedactor()
+	assert.Equal(t, "1", pc.formatNumber(issue))
+}
+
+func TestFormatRepo(t *testing.T) {
+	pc := &PRChecker{width: 110}
+	assert.Equal(t, "owner/repo", pc.formatRepo(createTestPRInRepo("PR", "owner/repo")))
+	assert.Equal(t, "owner/repo", pc.formatRepo(createTestPR("PR", "https://github.com/owner/repo/pull/1")))
+	assert.Equal(t, "", pc.formatRepo(createTestPR("PR", "url")))
+
+	// Long names are truncated to the repository column
+	long := alignCell(pc.formatRepo(createTestPRInRepo("PR", "organization/very-long-repository-name")), pc.layout(categoryCreated).repo, alignLeft)
+	assert.Equal(t, "organization/ver...", long)
+
+	pc.redact = newRedactor()
+	assert.Equal(t, "org-1/repo-1", pc.formatRepo(createTestPRInRepo("PR", "owner/repo")))
+}
+
 func TestDisplayPullRequests(t *testing.T) {
 	tests := []struct {
 		name     string
 		prs      []*github.Issue
 		category string
+		want     []string // Substrings of the rendered output
 		wantErr  bool
 	}{
 		{
 			name:     "empty PR list",
 			prs:      []*github.Issue{},
 			category: categoryCreated,
+			want:     []string{iconCreated + " Pull Requests Created by testuser", "No pull requests found"},
 		},
 		{
 			name: "invalid category",
@@ -341,14 +1291,17 @@ func TestDisplayPullRequests(t *testing.T) {
 				createTestPR("Test PR 2", "url2"),
 			},
 			category: categoryReviewer,
+			want:     []string{iconReviewer + " Review Requests for testuser", "Test PR 1", "Test PR 2", "url1", "url2"},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
 			pc := &PRChecker{
 				username:  "testuser",
 				formatter: NewDisplayFormatter(),
+				out:       &out,
 			}
 
 			err := pc.displayPullRequests(tt.prs, tt.category)
@@ -357,6 +1310,79 @@ func TestDisplayPullRequests(t *testing.T) {
 				return
 			}
 			assert.NoError(t, err)
+			for _, want := range tt.want {
+				assert.Contains(t, out.String(), want)
+			}
 		})
 	}
 }
+
+func TestRequestHeaders(t *testing.T) {
+	assert.Equal(t, map[string]string{
+		"Accept":         githubAcceptHeader,
+		headerAPIVersion: githubAPIVersion,
+	}, requestHeaders(githubAPIVersion))
+	assert.Equal(t, map[string]string{"Accept": githubAcceptHeader}, requestHeaders(""))
+}
+
+func TestColorDisabled(t *testing.T) {
+	env := map[string]string{}
+	getenv := func(key string) string { return env[key] }
+	assert.False(t, colorDisabled(false, getenv))
+	assert.True(t, colorDisabled(true, getenv))
+
+	env[envNoColor] = "1"
+	assert.True(t, colorDisabled(false, getenv))
+}
+
+func TestDisplayPullRequestsNoColor(t *testing.T) {
+	noColor := color.NoColor
+	defer func() { color.NoColor = noColor }()
+
+	var out bytes.Buffer
+	pc := &PRChecker{username: "testuser", formatter: NewDisplayFormatter(), out: &out}
+	issues := []*github.Issue{createTestPRInRepo("Test PR", "owner/repo")}
+
+	color.NoColor = false
+	assert.NoError(t, pc.displayPullRequests(issues, categoryCreated))
+	assert.Contains(t, out.String(), "\x1b[")
+
+	color.NoColor = true
+	out.Reset()
+	assert.NoError(t, pc.displayPullRequests(issues, categoryCreated))
+	output := out.String()
+	assert.NotContains(t, output, "\x1b[")
+
+	// Columns stay aligned with plain spaces
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	header, row := lines[len(lines)-3], lines[len(lines)-1]
+	assert.Equal(t, strings.Index(header, "Repo"), strings.Index(row, "owner/repo"))
+}
+
+func TestDisplayPullRequestsLayout(t *testing.T) {
+	now := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	recent := createTestPRInRepo("Fix flaky test", "owner/repo")
+	recent.Number = github.Int(7)
+	recent.UpdatedAt = &github.Timestamp{Time: now.Add(-2 * time.Hour)}
+	older := createTestPRInRepo("Add a much longer title that does not fit the title column", "owner/other")
+	older.Number = github.Int(1234)
+	older.User = &github.User{Login: github.String("alice")}
+	older.UpdatedAt = &github.Timestamp{Time: now.AddDate(0, 0, -3)}
+
+	noColor := color.NoColor
+	defer func() { color.NoColor = noColor }()
+	color.NoColor = true
+
+	var out bytes.Buffer
+	pc := &PRChecker{username: "testuser", formatter: NewDisplayFormatter(), out: &out, width: 80, clock: func() time.Time { return now }}
+	assert.NoError(t, pc.displayPullRequests([]*github.Issue{recent, older}, categoryReviewer))
+
+	assert.Equal(t, "\n"+
+		iconReviewer+" Review Requests for testuser\n"+
+		"\n"+
+		"     #  Title                Repo         Author    Updated            URL\n"+
+		strings.Repeat("-", fallbackTerminalWidth)+"\n"+
+		"     7  Fix flaky test       owner/repo   unknown   about 2 hours ago  https://github.com/owner/repo/pull/1\n"+
+		"  1234  Add a much longe...  owner/other  alice     about 3 days ago   https://github.com/owner/other/pull/1\n"+
+		"\n", out.String())
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/google/go-github/v67/github"
+)
+
+// writeMarkdown renders the results as GitHub-flavored Markdown, with a bold heading and
+// a table per category. Nothing is truncated since the width is not constrained.
+func (pc *PRChecker) writeMarkdown(w io.Writer, categories []string, results map[string][]*github.Issue) error {
+	now := pc.now()
+	for _, cat := range categories {
+		header, err := pc.sectionHeader(cat)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "**%s**\n\n", markdownCell(header))
+
+		issues := results[cat]
+		if len(issues) == 0 {
+			fmt.Fprint(w, "No pull requests found\n\n")
+			continue
+		}
+		fmt.Fprintf(w, "| Title | %s | URL |\n", timeFieldLabel(pc.opts.TimeField))
+		fmt.Fprint(w, "| --- | --- | --- |\n")
+		for _, issue := range issues {
+			record := pc.redact.record(newPRRecord(issue))
+			fmt.Fprintf(w, "| %s | %s | %s |\n", markdownCell(record.Title), pc.formatTime(issue, now), record.URL)
+		}
+		fmt.Fprintln(w)
+	}
+	return nil
+}
+
+// markdownCell makes text safe for a Markdown table cell, where line breaks would end the
+// row and unescaped pipes would split the cell
+func markdownCell(text string) string {
+	return strings.ReplaceAll(sanitizeTitle(text), "|", `\|`)
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v67/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteMarkdown(t *testing.T) {
+	updated := time.Date(2024, 1, 2, 15, 4, 0, 0, time.UTC)
+	pr := createTestPR("Fix | escape in a very long title that the terminal table would truncate", "https://github.com/owner/repo/pull/1")
+	pr.UpdatedAt = &github.Timestamp{Time: updated}
+
+	pc := &PRChecker{
+		username: "testuser",
+		opts:     Options{TimeField: timeFieldUpdated, TimeFormat: timeFormatAbsolute, Location: time.UTC},
+	}
+	results := map[string][]*github.Issue{categoryCreated: {pr}}
+
+	var buf bytes.Buffer
+	assert.NoError(t, pc.writeMarkdown(&buf, []string{categoryCreated, categoryReviewer}, results))
+	assert.Equal(t, `**🔨 Pull Requests Created by testuser**
+
+| Title | Updated | URL |
+| --- | --- | --- |
+| Fix \| escape in a very long title that the terminal table would truncate | 2024-01-02 15:04 | https://github.com/owner/repo/pull/1 |
+
+**👀 Review Requests for testuser**
+
+No pull requests found
+
+`, buf.String())
+}
+
+func TestMarkdownCell(t *testing.T) {
+	assert.Equal(t, `a \| b c`, markdownCell("a | b\nc"))
+}
+
+func TestWriteMarkdownRelativeTime(t *testing.T) {
+	now := time.Date(2024, 1, 2, 15, 4, 0, 0, time.UTC)
+	pr := createTestPR("Test PR", "https://github.com/owner/repo/pull/1")
+	pr.UpdatedAt = &github.Timestamp{Time: now.Add(-3 * time.Hour)}
+
+	pc := &PRChecker{
+		username: "testuser",
+		opts:     Options{TimeField: timeFieldUpdated, TimeFormat: timeFormatRelative},
+		clock:    func() time.Time { return now },
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, pc.writeMarkdown(&buf, []string{categoryCreated}, map[string][]*github.Issue{categoryCreated: {pr}}))
+	assert.Contains(t, buf.String(), "| Test PR | about 3 hours ago | https://github.com/owner/repo/pull/1 |")
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+
+	"github.com/google/go-github/v67/github"
+)
+
+// ndjsonLine is a line of the NDJSON output: a PR record tagged with its category
+type ndjsonLine struct {
+	Category string `json:"category"`
+	prRecord
+}
+
+// flusher is implemented by buffered writers whose lines must be pushed out right away
+type flusher interface {
+	Flush() error
+}
+
+// ndjsonWriter writes one compact JSON object per PR and line. Sections are written under
+// a lock so the lines of categories completing in parallel never interleave.
+type ndjsonWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// writeSection writes the lines of a category's records, flushing after each line so
+// downstream tools can process them as they arrive
+func (nw *ndjsonWriter) writeSection(category string, records []prRecord) error {
+	nw.mu.Lock()
+	defer nw.mu.Unlock()
+
+	encoder := json.NewEncoder(nw.w)
+	for _, record := range records {
+		if err := encoder.Encode(ndjsonLine{Category: category, prRecord: record}); err != nil {
+			return err
+		}
+		if f, ok := nw.w.(flusher); ok {
+			if err := f.Flush(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// writeNDJSON renders the records of every category as NDJSON lines, in category order
+func writeNDJSON(w io.Writer, categories []string, records map[string][]prRecord) error {
+	nw := &ndjsonWriter{w: w}
+	for _, cat := range categories {
+		if err := nw.writeSection(cat, records[cat]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ndjsonSection returns a sectionDone callback streaming each category's PRs as NDJSON
+// lines as soon as the category is collected
+func (pc *PRChecker) ndjsonSection() func(string, []*github.Issue) error {
+	nw := &ndjsonWriter{w: pc.writer()}
+	return func(category string, issues []*github.Issue) error {
+		records := newPRRecords(issues)
+		for i := range records {
+			records[i] = pc.redact.record(records[i])
+		}
+		return nw.writeSection(category, records)
+	}
+}
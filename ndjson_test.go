@@ -0,0 +1,116 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// decodeNDJSON splits NDJSON output on newlines and decodes each line
+func decodeNDJSON(t *testing.T, output string) []ndjsonLine {
+	t.Helper()
+	var lines []ndjsonLine
+	for _, text := range strings.Split(strings.TrimSuffix(output, "\n"), "\n") {
+		if text == "" {
+			continue
+		}
+		var line ndjsonLine
+		assert.NoError(t, json.Unmarshal([]byte(text), &line), text)
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func TestWriteNDJSON(t *testing.T) {
+	updated := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+	created := prRecord{Number: 1, Title: "Created", URL: "https://github.com/owner/repo/pull/1", Repo: "owner/repo", UpdatedAt: updated}
+	requested := prRecord{Number: 2, Title: "Requested", URL: "https://github.com/owner/repo/pull/2", Repo: "owner/repo", Author: "alice", UpdatedAt: updated}
+	records := map[string][]prRecord{
+		categoryCreated:  {created},
+		categoryReviewer: {requested},
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, writeNDJSON(&buf, []string{categoryCreated, categoryReviewer, categoryAssigned}, records))
+	assert.Equal(t, 2, strings.Count(buf.String(), "\n"))
+	assert.Contains(t, buf.String(), `{"category":"created","number":1,`)
+
+	assert.Equal(t, []ndjsonLine{
+		{Category: categoryCreated, prRecord: created},
+		{Category: categoryReviewer, prRecord: requested},
+	}, decodeNDJSON(t, buf.String()))
+}
+
+// countingFlusher records how many times it was flushed
+type countingFlusher struct {
+	bytes.Buffer
+	flushes int
+}
+
+func (f *countingFlusher) Flush() error {
+	f.flushes++
+	return nil
+}
+
+func TestNDJSONWriterFlushesEachLine(t *testing.T) {
+	out := &countingFlusher{}
+	nw := &ndjsonWriter{w: out}
+	assert.NoError(t, nw.writeSection(categoryCreated, []prRecord{{Number: 1}, {Number: 2}, {Number: 3}}))
+	assert.Equal(t, 3, out.flushes)
+	assert.Len(t, decodeNDJSON(t, out.String()), 3)
+}
+
+func TestNDJSONWriterConcurrentSections(t *testing.T) {
+	var buf bytes.Buffer
+	nw := &ndjsonWriter{w: &buf}
+
+	categories := []string{categoryCreated, categoryReviewer, categoryAssigned, categoryMentioned}
+	var wg sync.WaitGroup
+	for _, cat := range categories {
+		wg.Add(1)
+		go func(cat string) {
+			defer wg.Done()
+			records := make([]prRecord, 20)
+			for i := range records {
+				records[i] = prRecord{Number: i + 1, Title: fmt.Sprintf("%s %d", cat, i+1)}
+			}
+			assert.NoError(t, nw.writeSection(cat, records))
+		}(cat)
+	}
+	wg.Wait()
+
+	lines := decodeNDJSON(t, buf.String())
+	assert.Len(t, lines, 80)
+	for _, line := range lines {
+		assert.True(t, strings.HasPrefix(line.Title, line.Category+" "), line.Title)
+	}
+}
+
+func TestRunNDJSON(t *testing.T) {
+	var out bytes.Buffer
+	pc := &PRChecker{
+		client:    &MockGitHubClient{response: createTestPRList(createTestPRInRepo("Test PR", "owner/repo"))},
+		username:  "testuser",
+		formatter: NewDisplayFormatter(),
+		opts:      Options{Format: formatNDJSON, Categories: []string{categoryCreated, categoryReviewer}},
+		out:       &out,
+	}
+
+	assert.NoError(t, pc.Run())
+	lines := decodeNDJSON(t, out.String())
+	assert.Len(t, lines, 2)
+
+	var categories []string
+	for _, line := range lines {
+		categories = append(categories, line.Category)
+		assert.Equal(t, "Test PR", line.Title)
+		assert.Equal(t, "owner/repo", line.Repo)
+	}
+	assert.ElementsMatch(t, []string{categoryCreated, categoryReviewer}, categories)
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/browser"
+	"github.com/google/go-github/v67/github"
+)
+
+// maxOpenWithoutConfirm is the most PRs --open opens without asking first
+const maxOpenWithoutConfirm = 5
+
+// urlOpener opens a URL, usually in the web browser
+type urlOpener interface {
+	Browse(url string) error
+}
+
+// listedURLs returns the URL of every listed PR in display order, once each even when a
+// PR is listed in several sections
+func listedURLs(categories []string, results map[string][]*github.Issue) []string {
+	seen := make(map[string]bool)
+	var urls []string
+	for _, cat := range categories {
+		for _, issue := range results[cat] {
+			url := issue.GetHTMLURL()
+			if url != "" && !seen[url] {
+				seen[url] = true
+				urls = append(urls, url)
+			}
+		}
+	}
+	return urls
+}
+
+// openURLs opens each URL with opener. When more than maxOpenWithoutConfirm would open,
+// it asks on out and reads the answer from in, opening nothing unless it is yes.
+func openURLs(opener urlOpener, urls []string, in io.Reader, out io.Writer) error {
+	if len(urls) > maxOpenWithoutConfirm {
+		fmt.Fprintf(out, "Open %d pull requests in the browser? [y/N] ", len(urls))
+		answer, err := bufio.NewReader(in).ReadString('\n')
+		if err != nil && err != io.EOF {
+			return err
+		}
+		if answer = strings.ToLower(strings.TrimSpace(answer)); answer != "y" && answer != "yes" {
+			return nil
+		}
+	}
+	for _, url := range urls {
+		if err := opener.Browse(url); err != nil {
+			return fmt.Errorf("failed to open %s: %w", url, err)
+		}
+	}
+	return nil
+}
+
+// openListed opens the listed PRs for --open with pc.opener, or the web browser from
+// GH_BROWSER or BROWSER when it is nil
+func (pc *PRChecker) openListed(urls []string) error {
+	opener := pc.opener
+	if opener == nil {
+		opener = browser.New("", os.Stdout, os.Stderr)
+	}
+	return openURLs(opener, urls, os.Stdin, os.Stderr)
+}
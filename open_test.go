@@ -0,0 +1,84 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v67/github"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockOpener records the URLs it is asked to open
+type mockOpener struct {
+	urls []string
+	err  error
+}
+
+func (o *mockOpener) Browse(url string) error {
+	o.urls = append(o.urls, url)
+	return o.err
+}
+
+func TestListedURLs(t *testing.T) {
+	shared := createTestPR("Shared", "url1")
+	results := map[string][]*github.Issue{
+		categoryCreated:  {shared, createTestPR("Own", "url2")},
+		categoryReviewer: {createTestPR("Review", "url3"), shared},
+	}
+
+	assert.Equal(t, []string{"url3", "url1", "url2"}, listedURLs([]string{categoryReviewer, categoryCreated}, results))
+	assert.Empty(t, listedURLs([]string{categoryAssigned}, results))
+}
+
+func TestOpenURLs(t *testing.T) {
+	many := []string{"url1", "url2", "url3", "url4", "url5", "url6"}
+
+	tests := []struct {
+		name       string
+		urls       []string
+		input      string
+		wantOpened []string
+		wantPrompt bool
+	}{
+		{name: "few open without asking", urls: many[:maxOpenWithoutConfirm], wantOpened: many[:maxOpenWithoutConfirm]},
+		{name: "many confirmed", urls: many, input: "y\n", wantOpened: many, wantPrompt: true},
+		{name: "many confirmed in full", urls: many, input: " YES \n", wantOpened: many, wantPrompt: true},
+		{name: "many declined", urls: many, input: "n\n", wantPrompt: true},
+		{name: "many without an answer", urls: many, wantPrompt: true},
+		{name: "nothing listed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opener := &mockOpener{}
+			var out bytes.Buffer
+			assert.NoError(t, openURLs(opener, tt.urls, strings.NewReader(tt.input), &out))
+			assert.Equal(t, tt.wantOpened, opener.urls)
+			assert.Equal(t, tt.wantPrompt, strings.Contains(out.String(), "Open 6 pull requests in the browser?"))
+		})
+	}
+}
+
+func TestOpenURLsError(t *testing.T) {
+	opener := &mockOpener{err: fmt.Errorf("no browser")}
+	err := openURLs(opener, []string{"url1", "url2"}, strings.NewReader(""), &bytes.Buffer{})
+	assert.ErrorContains(t, err, "failed to open url1")
+	assert.Equal(t, []string{"url1"}, opener.urls)
+}
+
+func TestRunOpen(t *testing.T) {
+	opener := &mockOpener{}
+	pc := &PRChecker{
+		client:    &MockGitHubClient{response: createTestPRList(createTestPR("Test PR", "https://github.com/owner/repo/pull/1"))},
+		username:  "testuser",
+		formatter: NewDisplayFormatter(),
+		opener:    opener,
+		out:       &bytes.Buffer{},
+		opts:      Options{Open: true, Limit: 1, Categories: []string{categoryCreated, categoryReviewer}},
+	}
+
+	assert.NoError(t, pc.Run())
+	assert.Equal(t, []string{"https://github.com/owner/repo/pull/1"}, opener.urls)
+}
@@ -0,0 +1,393 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Output formats selectable with --format
+const (
+	formatTable    = "table"
+	formatJSON     = "json"
+	formatHTML     = "html"
+	formatMarkdown = "markdown"
+	formatCSV      = "csv"
+	formatYAML     = "yaml"
+	formatNDJSON   = "ndjson"
+	formatTSV      = "tsv"
+)
+
+// Options holds the command-line configuration for a run
+type Options struct {
+	TruncateURL   bool   // Truncate URLs so each row fits within the table width
+	OwnRepos      bool   // Only show created PRs in repositories the user owns or administers
+	Repo          string // Only show PRs in this "owner/name" repository
+	Org           string // Only show PRs in repositories of this organization or user
+	QueryExtra    string // Search qualifiers appended verbatim to every category's query
+	Team          string // "org/team" whose review requests are merged into the requested section
+	Drafts        bool   // Include draft PRs in the created section
+	Token         string // Auth token taking precedence over environment variables and gh auth
+	APIVersion    string // X-GitHub-Api-Version header value; the header is omitted when empty
+	JQ            string // jq expression applied to the JSON output
+	Verbose       bool   // Print diagnostic messages to stderr
+	PrintQuery    bool   // Print each category's search query to stderr instead of fetching results
+	FailIfPending bool   // Exit with exitPendingReviews when review requests are listed
+	NoColor       bool   // Disable colors and text styles
+	Color         string // Color mode: auto, always or never
+	Output        string // File the results are written to instead of stdout
+	Activity      bool   // Mark created PRs whose latest comment or review is from someone else
+	HTML          bool   // Render a standalone HTML page instead of the terminal table
+	Format        string // Output format; JSON and HTML are set to match it
+	Template      string // Go text/template executed once per category instead of the table
+	TimeField     string // Timestamp shown in the time column and used for sorting
+	ShowHidden    bool   // Report how many PRs client-side filters removed from each section
+	Sort          string // Sort key within sections; empty sorts by TimeField
+	Order         string // Sort order, asc or desc; empty uses the sort key's natural order
+	JSON          bool   // Print the results as JSON instead of the terminal table
+	PendingOnly   bool   // Only show review requests the user has not reviewed yet
+	TimeFormat    string // Time column format: relative or absolute
+	ShortURL      bool   // Show "owner/repo#123" instead of the full URL in the table
+	ShowBody      bool   // Show the first line of each PR body under its row
+	FailingChecks bool   // Only show PRs whose latest checks are failing
+	Checks        bool   // Show the state of each PR's latest checks
+	Reviews       bool   // Show the review decision of each created PR
+	Unresolved    bool   // Show the number of unresolved review threads of each PR
+	Comments      bool   // Show the number of comments on each PR
+	DiffStat      bool   // Show the lines added and removed by each created PR
+	Conflicts     bool   // Mark created PRs with merge conflicts
+	ReReview      bool   // Add the section of reviewed PRs with commits since the user's latest review
+	Labels        bool   // Show the labels of each PR
+	Compact       bool   // Show authors as colored badges of their initials
+	GraphQL       bool   // Fetch each category with its PR details in one GraphQL query
+	NoCache       bool   // Fetch every search again instead of using results cached by Cache
+	HasUnresolved bool   // Only show PRs with unresolved review threads
+	NewOnly       bool   // Only show PRs that are new or updated since they were last seen
+	ExternalOnly  bool   // Only show PRs from authors outside the repository's owner
+	ExcludeBots   bool   // Hide PRs authored by GitHub Apps such as Dependabot
+	Redact        bool   // Replace usernames, repositories and PR numbers with placeholders
+	Stream        bool   // Print each section as soon as its fetch completes
+	Watch         bool   // Clear the screen and refresh the results every Interval until interrupted
+	Open          bool   // Open the listed PRs in the web browser after displaying them
+	Copy          bool   // Copy the listed PRs' URLs to the clipboard after displaying them
+	Prompt        bool   // Print only the cached actionable count for shell prompts
+	StaleMarker   string // Appended to the prompt count when the cache is older than PromptTTL
+	MarkAllSeen   bool   // Mark every current PR as seen instead of displaying results
+	SortSections  bool   // Show the section with the most results first
+	GroupByRepo   bool   // Group each table section's PRs under a subheader per repository
+	ShowRateLimit bool   // Report the API rate limit after the run
+	MaxRetries    int    // Retries allowed across all requests in a run
+	Retries       int    // Attempts made for each request, including the first
+	Concurrency   int    // Maximum number of categories fetched at once
+	Limit         int    // Maximum number of PRs per section; zero or less means no cap
+	ColumnPadding int    // Spaces between table columns; columnPadding when zero
+
+	MinApprovals        int           // Only show created PRs with at least this many approvals
+	EnrichConcurrency   int           // Maximum number of PRs whose details are fetched at once
+	HasChangesRequested bool          // Only show created PRs with a changes request
+	PromptTTL           time.Duration // Age after which the prompt count is refreshed
+	Timeout             time.Duration // Deadline for resolving the username and for collecting; zero means none
+	Interval            time.Duration // Time between refreshes in watch mode
+	Stale               time.Duration // Age since the last update after which PRs are highlighted; zero disables
+	Since               sinceFilter   // Only show PRs updated at or after this cutoff; unset shows every PR
+	Cache               time.Duration // Age up to which cached search results are reused; zero disables caching
+
+	Categories []string          // Sections to show in order; every registered category when empty
+	Align      map[string]string // Table column alignments by column; left when unset
+	Hosts      []string          // Hosts to query and merge; the default host when empty
+	Location   *time.Location    // Time zone for absolute times; nil means the local zone
+
+	ExcludeAuthors []string   // Logins whose PRs are hidden, matched case-insensitively
+	IncludeLabels  [][]string // Label groups PRs must match, each with at least one of its labels
+	ExcludeLabels  []string   // Labels whose PRs are hidden
+	SectionOrder   []string   // Categories shown first in this order, ahead of the rest
+}
+
+// parseOptions parses command-line arguments into Options using the built-in defaults
+func parseOptions(args []string) (Options, error) {
+	return parseOptionsWithConfig(args, Config{})
+}
+
+// parseOptionsWithConfig parses command-line arguments into Options, with the values of
+// cfg taking the place of the built-in defaults
+func parseOptionsWithConfig(args []string, cfg Config) (Options, error) {
+	var opts Options
+	var tz, categories, sectionOrder, align string
+
+	fs := flag.NewFlagSet("gh-myprs", flag.ContinueOnError)
+	fs.BoolVar(&opts.TruncateURL, "truncate-url", false, "truncate URLs so each row fits within the display width")
+	fs.BoolVar(&opts.ShortURL, "short-url", false, `show "owner/repo#123" instead of the full URL in the table`)
+	fs.BoolVar(&opts.Redact, "redact", false, "replace usernames, repositories and PR numbers with placeholders for sharing screenshots")
+	fs.IntVar(&opts.ColumnPadding, "column-padding", columnPadding, "spaces between table columns")
+	fs.StringVar(&align, "align", "", `comma-separated column alignments, such as "time=right" (columns: number, title, repo, author, time, reviews, checks, threads, comments, url)`)
+	fs.BoolVar(&opts.ShowBody, "show-body", false, "show the first line of each PR description as a dimmed subtitle")
+	fs.StringVar(&opts.Repo, "repo", "", `only show PRs in the "owner/name" repository`)
+	fs.StringVar(&opts.Org, "org", "", "only show PRs in repositories owned by this organization or user")
+	fs.StringVar(&opts.QueryExtra, "query-extra", "", `search qualifiers appended to every section's query, such as "label:bug -author:app/dependabot"`)
+	fs.StringVar(&opts.Team, "team", "", `also list review requests sent to this "org/team" in the requested section`)
+	fs.BoolVar(&opts.Drafts, "drafts", false, "include your draft PRs in the created section")
+	fs.BoolVar(&opts.OwnRepos, "own-repos", false, "only show created PRs in repositories you own or administer")
+	fs.Var((*hostList)(&opts.Hosts), "host", "GitHub host to query, repeatable to merge results from several hosts (default: gh's default host)")
+	fs.StringVar(&opts.APIVersion, "api-version", githubAPIVersion, "REST API version to request; empty omits the header for older GitHub Enterprise Server releases")
+	fs.StringVar(&opts.Token, "token", "", "GitHub auth token (overrides GH_TOKEN, GITHUB_TOKEN and gh auth)")
+	fs.IntVar(&opts.Limit, "limit", 0, "maximum number of PRs shown per section (0 means no cap)")
+	fs.DurationVar(&opts.Timeout, "timeout", defaultTimeout, "time allowed for fetching the results, such as 30s (0 means no timeout)")
+	fs.DurationVar(&opts.Cache, "cache", 0, "reuse search results cached within this long, such as 5m (0 disables the cache)")
+	fs.BoolVar(&opts.NoCache, "no-cache", false, "fetch the searches again instead of using cached results, refreshing the cache")
+	fs.BoolVar(&opts.Watch, "watch", false, "clear the screen and refresh the results every --interval until interrupted")
+	fs.DurationVar(&opts.Interval, "interval", defaultWatchInterval, "time between refreshes with --watch, such as 30s")
+	fs.BoolVar(&opts.Open, "open", false, fmt.Sprintf("open the listed PRs in the web browser, asking first when there are more than %d", maxOpenWithoutConfirm))
+	fs.BoolVar(&opts.Copy, "copy", false, "copy the URLs of the listed PRs to the clipboard, one per line")
+	fs.IntVar(&opts.Concurrency, "concurrency", defaultConcurrency, "maximum number of sections fetched at once")
+	fs.IntVar(&opts.EnrichConcurrency, "enrich-concurrency", enrichConcurrency, "maximum number of PRs whose details, such as checks or reviews, are fetched at once per section")
+	fs.IntVar(&opts.Retries, "retries", maxAttemptsPerRequest, "attempts made for each request failing with a server or network error, including the first")
+	fs.IntVar(&opts.MaxRetries, "max-retries", defaultMaxRetries, "total retries of failed requests allowed across the whole run (0 disables retries)")
+	fs.BoolVar(&opts.ShowRateLimit, "show-rate-limit", false, "report the remaining API rate limit after the run (in JSON as a rate_limit object)")
+	fs.BoolVar(&opts.NoColor, "no-color", false, "disable colors and text styles (also disabled when NO_COLOR is set)")
+	opts.Color = colorAuto
+	fs.Var((*colorMode)(&opts.Color), "color", "when to use colors and text styles: auto, always (even with --output or when NO_COLOR is set) or never")
+	fs.StringVar(&opts.Output, "output", "", "write the results to this file instead of stdout, creating parent directories as needed")
+	fs.BoolVar(&opts.Verbose, "verbose", false, "print diagnostic messages to stderr")
+	fs.BoolVar(&opts.Activity, "activity", false, "mark created PRs whose latest comment or review is from someone else")
+	fs.StringVar(&opts.Format, "format", formatTable, "output format: table, json, html, markdown, csv, yaml, ndjson or tsv")
+	fs.BoolVar(&opts.HTML, "html", false, "render a standalone HTML page instead of the terminal table (same as --format html)")
+	fs.BoolVar(&opts.JSON, "json", false, "print the results as JSON instead of the terminal table (same as --format json)")
+	fs.StringVar(&opts.JQ, "jq", "", "filter the --json output with a jq expression")
+	fs.StringVar(&opts.Template, "template", "", "render each section with a Go text/template instead of the table")
+	fs.IntVar(&opts.MinApprovals, "min-approvals", 0, "only show created PRs with at least N approvals")
+	fs.BoolVar(&opts.HasChangesRequested, "has-changes-requested", false, "only show created PRs where a reviewer requested changes")
+	fs.BoolVar(&opts.Reviews, "reviews", false, "show whether each created PR is approved, has changes requested or still needs review")
+	fs.BoolVar(&opts.Checks, "checks", false, "show whether each PR's latest checks pass, fail or are pending")
+	fs.BoolVar(&opts.FailingChecks, "failing-checks", false, "only show PRs whose latest checks are failing")
+	fs.BoolVar(&opts.FailIfPending, "fail-if-pending", false, fmt.Sprintf("exit with status %d when there are review requests, for scripts and hooks", exitPendingReviews))
+	fs.BoolVar(&opts.PrintQuery, "print-query", false, "print the search query of each section to stderr and exit without fetching")
+	fs.BoolVar(&opts.GraphQL, "graphql", false, "fetch each section and its PR details in a single GraphQL query, falling back to REST")
+	fs.BoolVar(&opts.Unresolved, "unresolved", false, "show the number of unresolved review threads of each PR")
+	fs.BoolVar(&opts.Comments, "comments", false, "show the number of comments on each PR")
+	fs.BoolVar(&opts.ReReview, "rereview", false, "add a section of PRs you reviewed that have new commits since your latest review")
+	fs.BoolVar(&opts.Conflicts, "conflicts", false, "mark your PRs that have merge conflicts with their base branch")
+	fs.BoolVar(&opts.DiffStat, "diffstat", false, `show the lines added and removed by each of your PRs as "+X/-Y"`)
+	fs.BoolVar(&opts.Labels, "labels", false, "show the labels of each PR in their colors")
+	fs.BoolVar(&opts.Compact, "compact", false, "show authors as colored badges of their initials")
+	fs.BoolVar(&opts.HasUnresolved, "has-unresolved", false, "only show PRs with unresolved review threads")
+	fs.BoolVar(&opts.ExternalOnly, "external-only", false, "only show PRs from outside contributors rather than owners, members or collaborators")
+	fs.BoolVar(&opts.ExcludeBots, "exclude-bots", false, `hide PRs authored by bots, whose logins end in "[bot]"`)
+	fs.Var((*authorList)(&opts.ExcludeAuthors), "exclude-author", "hide PRs by this author, repeatable or comma-separated")
+	fs.Var((*labelGroups)(&opts.IncludeLabels), "label", "only show PRs with this label; comma-separated labels match any of them, and repeated flags must all match")
+	fs.Var((*labelList)(&opts.ExcludeLabels), "exclude-label", "hide PRs with this label, repeatable or comma-separated")
+	fs.BoolVar(&opts.NewOnly, "new-only", false, "only show PRs that are new or updated since they were last shown")
+	fs.BoolVar(&opts.MarkAllSeen, "mark-all-seen", false, "mark every current PR as seen for --new-only without displaying them")
+	fs.BoolVar(&opts.PendingOnly, "pending-only", false, "only show review requests you have not reviewed yet")
+	fs.StringVar(&opts.TimeFormat, "time-format", timeFormatRelative, "time column format: relative or absolute")
+	fs.StringVar(&tz, "tz", "", "IANA time zone for absolute times (default: local time zone)")
+	fs.StringVar(&categories, "categories", strings.Join(defaultCategories(), ","), "comma-separated sections to show, in order: "+strings.Join(registeredCategories(), ", "))
+	fs.StringVar(&opts.TimeField, "time-field", timeFieldUpdated, "timestamp to show and sort by: created or updated")
+	fs.BoolVar(&opts.ShowHidden, "show-hidden", false, "report how many PRs client-side filters hid in each section header")
+	fs.BoolVar(&opts.Prompt, "prompt", false, "print only the number of PRs needing your attention, cached for shell prompts")
+	fs.DurationVar(&opts.PromptTTL, "prompt-ttl", defaultPromptTTL, "age after which --prompt refreshes its cached count")
+	fs.StringVar(&opts.StaleMarker, "stale-marker", defaultStaleMarker, "appended to the --prompt count when it could not be refreshed")
+	fs.BoolVar(&opts.Stream, "stream", false, "print each section as soon as it is fetched instead of in a fixed order")
+	fs.StringVar(&sectionOrder, "order-sections", "", "comma-separated sections shown first in this order, such as \"requested,created\"; the rest follow")
+	fs.BoolVar(&opts.SortSections, "sort-sections", false, "show sections with the most pull requests first")
+	fs.BoolVar(&opts.GroupByRepo, "group-by-repo", false, "group the PRs of each table section by repository, most recently updated first")
+	fs.Var(&opts.Since, "since", `only show PRs updated within an age such as "24h" or "7d", or since a date such as "2024-01-01"`)
+	fs.Var((*ageValue)(&opts.Stale), "stale", `highlight PRs whose --time-field time is older than this, such as "7d" or "36h"`)
+	fs.StringVar(&opts.Sort, "sort", "", "sort PRs within sections by: updated, created, title or urgency (default: --time-field)")
+	fs.StringVar(&opts.Order, "order", "", "sort order: asc or desc (default: desc, or asc for --sort title)")
+
+	if err := fs.Parse(args); err != nil {
+		return Options{}, err
+	}
+	if fs.NArg() > 0 {
+		return Options{}, fmt.Errorf("unexpected argument: %s", fs.Arg(0))
+	}
+	onCommandLine := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { onCommandLine[f.Name] = true })
+	if err := cfg.apply(fs, onCommandLine); err != nil {
+		return Options{}, err
+	}
+
+	for _, cat := range strings.Split(categories, ",") {
+		cat = strings.TrimSpace(cat)
+		if _, _, err := sectionTitle(cat); err != nil {
+			return Options{}, fmt.Errorf("invalid --categories %q: %w", categories, err)
+		}
+		opts.Categories = append(opts.Categories, cat)
+	}
+	if sectionOrder != "" {
+		order, err := parseSectionOrder(sectionOrder)
+		if err != nil {
+			return Options{}, fmt.Errorf("invalid --order-sections %q: %w", sectionOrder, err)
+		}
+		opts.SectionOrder = order
+	}
+	if opts.ReReview && !slices.Contains(opts.Categories, categoryReReview) {
+		opts.Categories = append(opts.Categories, categoryReReview)
+	}
+	if opts.Repo != "" {
+		if err := validateRepo(opts.Repo); err != nil {
+			return Options{}, fmt.Errorf("invalid --repo: %w", err)
+		}
+	}
+	if opts.Org != "" {
+		if err := validateOrg(opts.Org); err != nil {
+			return Options{}, fmt.Errorf("invalid --org: %w", err)
+		}
+	}
+	if opts.Team != "" {
+		if err := validateTeam(opts.Team); err != nil {
+			return Options{}, fmt.Errorf("invalid --team: %w", err)
+		}
+	}
+	if opts.QueryExtra != "" {
+		if err := validateQueryExtra(opts.QueryExtra); err != nil {
+			return Options{}, fmt.Errorf("invalid --query-extra: %w", err)
+		}
+	}
+	alignments, err := parseAlignments(align)
+	if err != nil {
+		return Options{}, fmt.Errorf("invalid --align %q: %w", align, err)
+	}
+	opts.Align = alignments
+	if opts.ColumnPadding < 1 || opts.ColumnPadding > maxColumnPadding {
+		return Options{}, fmt.Errorf("invalid --column-padding %d: must be between 1 and %d", opts.ColumnPadding, maxColumnPadding)
+	}
+	if opts.TimeField != timeFieldUpdated && opts.TimeField != timeFieldCreated {
+		return Options{}, fmt.Errorf("invalid --time-field %q: must be created or updated", opts.TimeField)
+	}
+	if opts.TimeFormat != timeFormatRelative && opts.TimeFormat != timeFormatAbsolute {
+		return Options{}, fmt.Errorf("invalid --time-format %q: must be relative or absolute", opts.TimeFormat)
+	}
+	if tz != "" {
+		loc, err := time.LoadLocation(tz)
+		if err != nil {
+			return Options{}, fmt.Errorf("invalid --tz %q: %w", tz, err)
+		}
+		opts.Location = loc
+	}
+	if len(opts.Hosts) > 1 && opts.Token != "" {
+		return Options{}, fmt.Errorf("--token cannot be used with more than one --host")
+	}
+	if opts.MinApprovals < 0 {
+		return Options{}, fmt.Errorf("invalid --min-approvals %d: must not be negative", opts.MinApprovals)
+	}
+	if opts.Concurrency < 1 {
+		return Options{}, fmt.Errorf("invalid --concurrency %d: must be at least 1", opts.Concurrency)
+	}
+	if opts.EnrichConcurrency < 1 {
+		return Options{}, fmt.Errorf("invalid --enrich-concurrency %d: must be at least 1", opts.EnrichConcurrency)
+	}
+	if opts.Timeout < 0 {
+		return Options{}, fmt.Errorf("invalid --timeout %s: must not be negative", opts.Timeout)
+	}
+	if opts.Cache < 0 {
+		return Options{}, fmt.Errorf("invalid --cache %s: must not be negative", opts.Cache)
+	}
+	if opts.Cache > 0 && opts.GraphQL {
+		return Options{}, fmt.Errorf("--cache cannot be used with --graphql")
+	}
+	if opts.Stale < 0 {
+		return Options{}, fmt.Errorf("invalid --stale %s: must not be negative", opts.Stale)
+	}
+	if opts.Interval <= 0 {
+		return Options{}, fmt.Errorf("invalid --interval %s: must be positive", opts.Interval)
+	}
+	if opts.Retries < 1 {
+		return Options{}, fmt.Errorf("invalid --retries %d: must be at least 1", opts.Retries)
+	}
+	if opts.MaxRetries < 0 {
+		return Options{}, fmt.Errorf("invalid --max-retries %d: must not be negative", opts.MaxRetries)
+	}
+	if opts.JSON && opts.HTML {
+		return Options{}, fmt.Errorf("--json and --html cannot be used together")
+	}
+	switch opts.Format {
+	case formatTable, formatJSON, formatHTML, formatMarkdown, formatCSV, formatYAML, formatNDJSON, formatTSV:
+	default:
+		return Options{}, fmt.Errorf("invalid --format %q: must be table, json, html, markdown, csv, yaml, ndjson or tsv", opts.Format)
+	}
+	if shortcut := shortcutFormat(opts); shortcut != "" {
+		if opts.Format != formatTable && opts.Format != shortcut {
+			return Options{}, fmt.Errorf("--%s cannot be used with --format %s", shortcut, opts.Format)
+		}
+		opts.Format = shortcut
+	}
+	opts.JSON = opts.Format == formatJSON
+	opts.HTML = opts.Format == formatHTML
+	if opts.JQ != "" {
+		if !opts.JSON {
+			return Options{}, fmt.Errorf("--jq requires --json")
+		}
+		if err := validateJQ(opts.JQ); err != nil {
+			return Options{}, fmt.Errorf("invalid --jq expression %q: %w", opts.JQ, err)
+		}
+	}
+	if opts.Template != "" {
+		if opts.Format != formatTable {
+			return Options{}, fmt.Errorf("--template cannot be used with --format %s", opts.Format)
+		}
+		if _, err := parseTemplate(opts.Template, time.Now); err != nil {
+			return Options{}, fmt.Errorf("invalid --template: %w", err)
+		}
+	}
+	if opts.SortSections && len(opts.SectionOrder) > 0 {
+		return Options{}, fmt.Errorf("--sort-sections and --order-sections cannot be used together")
+	}
+	if opts.Stream && (opts.Format != formatTable || opts.Template != "" || opts.SortSections || len(opts.SectionOrder) > 0 || len(opts.Hosts) > 1) {
+		return Options{}, fmt.Errorf("--stream only works with the table output of a single host and without --sort-sections or --order-sections")
+	}
+	if opts.Prompt && (opts.Format != formatTable || opts.Template != "" || opts.Stream || opts.MarkAllSeen || len(opts.Hosts) > 1) {
+		return Options{}, fmt.Errorf("--prompt cannot be combined with other outputs, --mark-all-seen or more than one --host")
+	}
+	if opts.Watch && (opts.Format != formatTable || opts.Prompt || opts.MarkAllSeen || len(opts.Hosts) > 1) {
+		return Options{}, fmt.Errorf("--watch only works with the table output of a single host and without --prompt or --mark-all-seen")
+	}
+	if opts.FailIfPending && (opts.Watch || opts.Prompt || opts.PrintQuery) {
+		return Options{}, fmt.Errorf("--fail-if-pending cannot be used with --watch, --prompt or --print-query")
+	}
+	if opts.FailIfPending && !slices.Contains(opts.Categories, categoryReviewer) {
+		return Options{}, fmt.Errorf("--fail-if-pending requires the %s section in --categories", categoryReviewer)
+	}
+	if opts.PrintQuery && opts.Prompt {
+		return Options{}, fmt.Errorf("--print-query cannot be used with --prompt")
+	}
+	// A no-color default from the config file gives way to --color=always
+	if opts.Color == colorAlways && opts.NoColor && onCommandLine["color"] && onCommandLine["no-color"] {
+		return Options{}, fmt.Errorf("--color=always and --no-color cannot be used together")
+	}
+	if opts.Output != "" && (opts.Watch || opts.Prompt) {
+		return Options{}, fmt.Errorf("--output cannot be used with --watch or --prompt")
+	}
+	if opts.Open && (opts.Watch || opts.Prompt || opts.MarkAllSeen) {
+		return Options{}, fmt.Errorf("--open cannot be used with --watch, --prompt or --mark-all-seen")
+	}
+	if opts.Copy && (opts.Watch || opts.Prompt || opts.MarkAllSeen) {
+		return Options{}, fmt.Errorf("--copy cannot be used with --watch, --prompt or --mark-all-seen")
+	}
+	if opts.PromptTTL < 0 {
+		return Options{}, fmt.Errorf("invalid --prompt-ttl %s: must not be negative", opts.PromptTTL)
+	}
+	switch opts.Sort {
+	case "", sortUpdated, sortCreated, sortTitle, sortUrgency:
+	default:
+		return Options{}, fmt.Errorf("invalid --sort %q: must be updated, created, title or urgency", opts.Sort)
+	}
+	if opts.Order != "" && opts.Order != orderAsc && opts.Order != orderDesc {
+		return Options{}, fmt.Errorf("invalid --order %q: must be asc or desc", opts.Order)
+	}
+	return opts, nil
+}
+
+// shortcutFormat returns the format selected by --json or --html, or "" when neither is set
+func shortcutFormat(opts Options) string {
+	switch {
+	case opts.JSON:
+		return formatJSON
+	case opts.HTML:
+		return formatHTML
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,680 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseOptions(t *testing.T) {
+	defaults, err := parseOptions(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, timeFieldUpdated, defaults.TimeField)
+	assert.Equal(t, timeFormatRelative, defaults.TimeFormat)
+	assert.Equal(t, defaultMaxRetries, defaults.MaxRetries)
+	assert.Equal(t, maxAttemptsPerRequest, defaults.Retries)
+	assert.Equal(t, defaultConcurrency, defaults.Concurrency)
+	assert.Equal(t, githubAPIVersion, defaults.APIVersion)
+	assert.Equal(t, defaultTimeout, defaults.Timeout)
+	assert.Equal(t, defaultWatchInterval, defaults.Interval)
+	assert.Equal(t, defaultCategories(), defaults.Categories)
+	assert.Equal(t, colorAuto, defaults.Color)
+
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		args    []string
+		want    func(*Options) // Modifies the defaults into the expected options
+		wantErr bool
+	}{
+		{
+			name: "defaults",
+			args: []string{},
+			want: func(*Options) {},
+		},
+		{
+			name: "truncate url",
+			args: []string{"--truncate-url"},
+			want: func(o *Options) { o.TruncateURL = true },
+		},
+		{
+			name: "token and verbose",
+			args: []string{"--token", "secret", "--verbose"},
+			want: func(o *Options) {
+				o.Token = "secret"
+				o.Verbose = true
+			},
+		},
+		{
+			name: "created time field",
+			args: []string{"--time-field", "created"},
+			want: func(o *Options) { o.TimeField = timeFieldCreated },
+		},
+		{
+			name:    "invalid time field",
+			args:    []string{"--time-field", "merged"},
+			wantErr: true,
+		},
+		{
+			name: "urgency sort",
+			args: []string{"--sort", "urgency"},
+			want: func(o *Options) { o.Sort = sortUrgency },
+		},
+		{
+			name: "repo",
+			args: []string{"--repo", "owner/repo"},
+			want: func(o *Options) { o.Repo = "owner/repo" },
+		},
+		{
+			name:    "repo without slash",
+			args:    []string{"--repo", "repo"},
+			wantErr: true,
+		},
+		{
+			name: "org",
+			args: []string{"--org", "my-org"},
+			want: func(o *Options) { o.Org = "my-org" },
+		},
+		{
+			name:    "org with consecutive hyphens",
+			args:    []string{"--org", "my--org"},
+			wantErr: true,
+		},
+		{
+			name: "team review requests",
+			args: []string{"--team", "my-org/reviewers"},
+			want: func(o *Options) { o.Team = "my-org/reviewers" },
+		},
+		{
+			name:    "team without org",
+			args:    []string{"--team", "reviewers"},
+			wantErr: true,
+		},
+		{
+			name: "extra search qualifiers",
+			args: []string{"--query-extra", "label:bug -author:someone"},
+			want: func(o *Options) { o.QueryExtra = "label:bug -author:someone" },
+		},
+		{
+			name:    "blank extra search qualifiers",
+			args:    []string{"--query-extra", "  "},
+			wantErr: true,
+		},
+		{
+			name:    "extra search qualifiers with a control character",
+			args:    []string{"--query-extra", "label:bug\tx"},
+			wantErr: true,
+		},
+		{
+			name: "drafts",
+			args: []string{"--drafts"},
+			want: func(o *Options) { o.Drafts = true },
+		},
+		{
+			name: "external only",
+			args: []string{"--external-only"},
+			want: func(o *Options) { o.ExternalOnly = true },
+		},
+		{
+			name: "new only",
+			args: []string{"--new-only"},
+			want: func(o *Options) { o.NewOnly = true },
+		},
+		{
+			name: "failing checks",
+			args: []string{"--failing-checks"},
+			want: func(o *Options) { o.FailingChecks = true },
+		},
+		{
+			name: "reviews column",
+			args: []string{"--reviews"},
+			want: func(o *Options) { o.Reviews = true },
+		},
+		{
+			name: "comments column",
+			args: []string{"--comments", "--align", "comments=left"},
+			want: func(o *Options) {
+				o.Comments = true
+				o.Align = map[string]string{columnComments: alignLeft}
+			},
+		},
+		{
+			name: "conflict markers",
+			args: []string{"--conflicts"},
+			want: func(o *Options) { o.Conflicts = true },
+		},
+		{
+			name: "diffstat column",
+			args: []string{"--diffstat"},
+			want: func(o *Options) { o.DiffStat = true },
+		},
+		{
+			name: "exclude authors",
+			args: []string{"--exclude-bots", "--exclude-author", "alice", "--exclude-author", "bob,renovate"},
+			want: func(o *Options) {
+				o.ExcludeBots = true
+				o.ExcludeAuthors = []string{"alice", "bob", "renovate"}
+			},
+		},
+		{
+			name: "label filters",
+			args: []string{"--label", "priority,urgent", "--label", "backend", "--exclude-label", "wontfix"},
+			want: func(o *Options) {
+				o.IncludeLabels = [][]string{{"priority", "urgent"}, {"backend"}}
+				o.ExcludeLabels = []string{"wontfix"}
+			},
+		},
+		{
+			name: "cache",
+			args: []string{"--cache", "5m", "--no-cache"},
+			want: func(o *Options) {
+				o.Cache = 5 * time.Minute
+				o.NoCache = true
+			},
+		},
+		{
+			name: "print query",
+			args: []string{"--print-query"},
+			want: func(o *Options) { o.PrintQuery = true },
+		},
+		{
+			name: "enrich concurrency",
+			args: []string{"--enrich-concurrency", "10"},
+			want: func(o *Options) { o.EnrichConcurrency = 10 },
+		},
+		{
+			name:    "zero enrich concurrency",
+			args:    []string{"--enrich-concurrency", "0"},
+			wantErr: true,
+		},
+		{
+			name: "labels column",
+			args: []string{"--labels"},
+			want: func(o *Options) { o.Labels = true },
+		},
+		{
+			name: "compact author badges",
+			args: []string{"--compact"},
+			want: func(o *Options) { o.Compact = true },
+		},
+		{
+			name: "checks column",
+			args: []string{"--checks", "--align", "checks=right"},
+			want: func(o *Options) {
+				o.Checks = true
+				o.Align = map[string]string{columnChecks: alignRight}
+			},
+		},
+		{
+			name: "column padding and alignment",
+			args: []string{"--column-padding", "3", "--align", "time=right, url=left"},
+			want: func(o *Options) {
+				o.ColumnPadding = 3
+				o.Align = map[string]string{columnTime: alignRight, columnURL: alignLeft}
+			},
+		},
+		{
+			name:    "zero column padding",
+			args:    []string{"--column-padding", "0"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown alignment",
+			args:    []string{"--align", "time=center"},
+			wantErr: true,
+		},
+		{
+			name: "prompt",
+			args: []string{"--prompt", "--prompt-ttl", "10m", "--stale-marker", "?"},
+			want: func(o *Options) {
+				o.Prompt = true
+				o.PromptTTL = 10 * time.Minute
+				o.StaleMarker = "?"
+			},
+		},
+		{
+			name:    "prompt with json",
+			args:    []string{"--prompt", "--json"},
+			wantErr: true,
+		},
+		{
+			name: "jq",
+			args: []string{"--json", "--jq", ".created[].url"},
+			want: func(o *Options) {
+				o.JSON = true
+				o.Format = formatJSON
+				o.JQ = ".created[].url"
+			},
+		},
+		{
+			name:    "jq without json",
+			args:    []string{"--jq", ".created"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid jq",
+			args:    []string{"--json", "--jq", ".created["},
+			wantErr: true,
+		},
+		{
+			name: "unresolved threads",
+			args: []string{"--unresolved", "--has-unresolved"},
+			want: func(o *Options) {
+				o.Unresolved = true
+				o.HasUnresolved = true
+			},
+		},
+		{
+			name: "limit",
+			args: []string{"--limit", "10"},
+			want: func(o *Options) { o.Limit = 10 },
+		},
+		{
+			name: "api version",
+			args: []string{"--api-version", ""},
+			want: func(o *Options) { o.APIVersion = "" },
+		},
+		{
+			name: "stream",
+			args: []string{"--stream"},
+			want: func(o *Options) { o.Stream = true },
+		},
+		{
+			name:    "stream with json",
+			args:    []string{"--stream", "--json"},
+			wantErr: true,
+		},
+		{
+			name:    "stream with sorted sections",
+			args:    []string{"--stream", "--sort-sections"},
+			wantErr: true,
+		},
+		{
+			name: "redact",
+			args: []string{"--redact"},
+			want: func(o *Options) { o.Redact = true },
+		},
+		{
+			name: "show body",
+			args: []string{"--show-body"},
+			want: func(o *Options) { o.ShowBody = true },
+		},
+		{
+			name: "show rate limit",
+			args: []string{"--show-rate-limit"},
+			want: func(o *Options) { o.ShowRateLimit = true },
+		},
+		{
+			name: "group by repo",
+			args: []string{"--group-by-repo"},
+			want: func(o *Options) { o.GroupByRepo = true },
+		},
+		{
+			name: "stale in days",
+			args: []string{"--stale", "7d"},
+			want: func(o *Options) { o.Stale = 7 * 24 * time.Hour },
+		},
+		{
+			name:    "negative stale",
+			args:    []string{"--stale", "-2d"},
+			wantErr: true,
+		},
+		{
+			name: "sort sections",
+			args: []string{"--sort-sections"},
+			want: func(o *Options) { o.SortSections = true },
+		},
+		{
+			name: "fail if pending",
+			args: []string{"--fail-if-pending"},
+			want: func(o *Options) { o.FailIfPending = true },
+		},
+		{
+			name:    "fail if pending without review requests",
+			args:    []string{"--fail-if-pending", "--categories", "created"},
+			wantErr: true,
+		},
+		{
+			name:    "fail if pending while watching",
+			args:    []string{"--fail-if-pending", "--watch"},
+			wantErr: true,
+		},
+		{
+			name: "section order",
+			args: []string{"--order-sections", "requested,created"},
+			want: func(o *Options) { o.SectionOrder = []string{categoryReviewer, categoryCreated} },
+		},
+		{
+			name:    "section order with an unknown section",
+			args:    []string{"--order-sections", "requested,later"},
+			wantErr: true,
+		},
+		{
+			name:    "section order with sorted sections",
+			args:    []string{"--order-sections", "requested", "--sort-sections"},
+			wantErr: true,
+		},
+		{
+			name:    "section order with streaming",
+			args:    []string{"--order-sections", "requested", "--stream"},
+			wantErr: true,
+		},
+		{
+			name: "title sort in descending order",
+			args: []string{"--sort", "title", "--order", "desc"},
+			want: func(o *Options) {
+				o.Sort = sortTitle
+				o.Order = orderDesc
+			},
+		},
+		{
+			name:    "invalid order",
+			args:    []string{"--order", "up"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid sort",
+			args:    []string{"--sort", "stars"},
+			wantErr: true,
+		},
+		{
+			name: "json output",
+			args: []string{"--json"},
+			want: func(o *Options) {
+				o.JSON = true
+				o.Format = formatJSON
+			},
+		},
+		{
+			name: "json format",
+			args: []string{"--format", "json"},
+			want: func(o *Options) {
+				o.JSON = true
+				o.Format = formatJSON
+			},
+		},
+		{
+			name: "markdown format",
+			args: []string{"--format", "markdown"},
+			want: func(o *Options) { o.Format = formatMarkdown },
+		},
+		{
+			name: "csv format",
+			args: []string{"--format", "csv"},
+			want: func(o *Options) { o.Format = formatCSV },
+		},
+		{
+			name: "yaml format",
+			args: []string{"--format", "yaml"},
+			want: func(o *Options) { o.Format = formatYAML },
+		},
+		{
+			name: "ndjson format",
+			args: []string{"--format", "ndjson"},
+			want: func(o *Options) { o.Format = formatNDJSON },
+		},
+		{
+			name: "tsv format",
+			args: []string{"--format", "tsv"},
+			want: func(o *Options) { o.Format = formatTSV },
+		},
+		{
+			name: "template",
+			args: []string{"--template", "{{range .PRs}}{{.URL}}\n{{end}}"},
+			want: func(o *Options) { o.Template = "{{range .PRs}}{{.URL}}\n{{end}}" },
+		},
+		{
+			name:    "malformed template",
+			args:    []string{"--template", "{{range .PRs}}"},
+			wantErr: true,
+		},
+		{
+			name:    "template with another format",
+			args:    []string{"--template", "{{.Category}}", "--json"},
+			wantErr: true,
+		},
+		{
+			name:    "json with another format",
+			args:    []string{"--json", "--format", "markdown"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown format",
+			args:    []string{"--format", "xml"},
+			wantErr: true,
+		},
+		{
+			name:    "json and html together",
+			args:    []string{"--json", "--html"},
+			wantErr: true,
+		},
+		{
+			name: "absolute time in a zone",
+			args: []string{"--time-format", "absolute", "--tz", "Asia/Tokyo"},
+			want: func(o *Options) {
+				o.TimeFormat = timeFormatAbsolute
+				o.Location = tokyo
+			},
+		},
+		{
+			name:    "invalid time format",
+			args:    []string{"--time-format", "iso"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid time zone",
+			args:    []string{"--tz", "Mars/Olympus_Mons"},
+			wantErr: true,
+		},
+		{
+			name: "review thresholds",
+			args: []string{"--min-approvals", "2", "--has-changes-requested"},
+			want: func(o *Options) {
+				o.MinApprovals = 2
+				o.HasChangesRequested = true
+			},
+		},
+		{
+			name:    "negative min approvals",
+			args:    []string{"--min-approvals", "-1"},
+			wantErr: true,
+		},
+		{
+			name: "no timeout",
+			args: []string{"--timeout", "0"},
+			want: func(o *Options) { o.Timeout = 0 },
+		},
+		{
+			name:    "negative timeout",
+			args:    []string{"--timeout", "-1s"},
+			wantErr: true,
+		},
+		{
+			name:    "negative cache",
+			args:    []string{"--cache", "-1m"},
+			wantErr: true,
+		},
+		{
+			name:    "cache with graphql",
+			args:    []string{"--cache", "5m", "--graphql"},
+			wantErr: true,
+		},
+		{
+			name:    "timeout without unit",
+			args:    []string{"--timeout", "30"},
+			wantErr: true,
+		},
+		{
+			name: "watch",
+			args: []string{"--watch", "--interval", "1m"},
+			want: func(o *Options) {
+				o.Watch = true
+				o.Interval = time.Minute
+			},
+		},
+		{
+			name: "open",
+			args: []string{"--open"},
+			want: func(o *Options) { o.Open = true },
+		},
+		{
+			name: "output file with forced colors",
+			args: []string{"--output", "prs.txt", "--color=always"},
+			want: func(o *Options) {
+				o.Output = "prs.txt"
+				o.Color = colorAlways
+			},
+		},
+		{
+			name: "never color",
+			args: []string{"--color=never"},
+			want: func(o *Options) { o.Color = colorNever },
+		},
+		{
+			name: "auto color",
+			args: []string{"--color=auto"},
+			want: func(o *Options) { o.Color = colorAuto },
+		},
+		{
+			name: "color mode as a separate argument",
+			args: []string{"--color", "never"},
+			want: func(o *Options) { o.Color = colorNever },
+		},
+		{
+			name:    "color without a mode",
+			args:    []string{"--color"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid color mode",
+			args:    []string{"--color=sometimes"},
+			wantErr: true,
+		},
+		{
+			name: "never color with no color",
+			args: []string{"--color=never", "--no-color"},
+			want: func(o *Options) {
+				o.Color = colorNever
+				o.NoColor = true
+			},
+		},
+		{
+			name:    "output with watch",
+			args:    []string{"--output", "prs.txt", "--watch"},
+			wantErr: true,
+		},
+		{
+			name:    "color and no color",
+			args:    []string{"--color", "always", "--no-color"},
+			wantErr: true,
+		},
+		{
+			name: "copy",
+			args: []string{"--copy"},
+			want: func(o *Options) { o.Copy = true },
+		},
+		{
+			name:    "copy with prompt",
+			args:    []string{"--copy", "--prompt"},
+			wantErr: true,
+		},
+		{
+			name:    "open with watch",
+			args:    []string{"--open", "--watch"},
+			wantErr: true,
+		},
+		{
+			name:    "zero interval",
+			args:    []string{"--watch", "--interval", "0s"},
+			wantErr: true,
+		},
+		{
+			name:    "watch with json",
+			args:    []string{"--watch", "--json"},
+			wantErr: true,
+		},
+		{
+			name: "concurrency",
+			args: []string{"--concurrency", "1"},
+			want: func(o *Options) { o.Concurrency = 1 },
+		},
+		{
+			name:    "zero concurrency",
+			args:    []string{"--concurrency", "0"},
+			wantErr: true,
+		},
+		{
+			name: "retries",
+			args: []string{"--retries", "5"},
+			want: func(o *Options) { o.Retries = 5 },
+		},
+		{
+			name:    "zero retries",
+			args:    []string{"--retries", "0"},
+			wantErr: true,
+		},
+		{
+			name: "max retries",
+			args: []string{"--max-retries", "0"},
+			want: func(o *Options) { o.MaxRetries = 0 },
+		},
+		{
+			name:    "negative max retries",
+			args:    []string{"--max-retries", "-1"},
+			wantErr: true,
+		},
+		{
+			name: "categories in custom order",
+			args: []string{"--categories", "requested, created,requested"},
+			want: func(o *Options) { o.Categories = []string{categoryReviewer, categoryCreated, categoryReviewer} },
+		},
+		{
+			name:    "unknown category",
+			args:    []string{"--categories", "created,merged"},
+			wantErr: true,
+		},
+		{
+			name: "repeated host",
+			args: []string{"--host", "github.com", "--host", "ghe.example.com"},
+			want: func(o *Options) { o.Hosts = []string{"github.com", "ghe.example.com"} },
+		},
+		{
+			name:    "token with several hosts",
+			args:    []string{"--host", "github.com", "--host", "ghe.example.com", "--token", "secret"},
+			wantErr: true,
+		},
+		{
+			name:    "empty host",
+			args:    []string{"--host", ""},
+			wantErr: true,
+		},
+		{
+			name:    "unknown flag",
+			args:    []string{"--unknown"},
+			wantErr: true,
+		},
+		{
+			name:    "unexpected argument",
+			args:    []string{"extra"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseOptions(tt.args)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+
+			want := defaults
+			tt.want(&want)
+			assert.Equal(t, want, got)
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/go-github/v67/github"
+)
+
+// prRecord is the format-independent representation of a PR shared by structured outputs
+type prRecord struct {
+	Number            int       `json:"number" yaml:"number"`
+	Title             string    `json:"title" yaml:"title"`
+	URL               string    `json:"url" yaml:"url"`
+	Repo              string    `json:"repo" yaml:"repo"`
+	Author            string    `json:"author" yaml:"author"`
+	AuthorAssociation string    `json:"author_association" yaml:"author_association"`
+	CreatedAt         time.Time `json:"created_at" yaml:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at" yaml:"updated_at"`
+	Host              string    `json:"host,omitempty" yaml:"host,omitempty"` // Set when several hosts are queried
+}
+
+// machineReadable reports whether the selected output is meant for other programs
+func machineReadable(opts Options) bool {
+	if opts.JSON {
+		return true
+	}
+	switch opts.Format {
+	case formatCSV, formatYAML, formatNDJSON, formatTSV:
+		return true
+	default:
+		return false
+	}
+}
+
+// newPRRecord converts an issue from the search results into a prRecord
+func newPRRecord(issue *github.Issue) prRecord {
+	return prRecord{
+		Number:            issue.GetNumber(),
+		Title:             issue.GetTitle(),
+		URL:               issue.GetHTMLURL(),
+		Repo:              repoFromIssue(issue),
+		Author:            issue.GetUser().GetLogin(),
+		AuthorAssociation: authorAssociation(issue),
+		CreatedAt:         issue.GetCreatedAt().Time,
+		UpdatedAt:         issue.GetUpdatedAt().Time,
+	}
+}
+
+// newPRRecords converts a list of issues into prRecords
+func newPRRecords(issues []*github.Issue) []prRecord {
+	records := make([]prRecord, 0, len(issues))
+	for _, issue := range issues {
+		records = append(records, newPRRecord(issue))
+	}
+	return records
+}
+
+// recordsByCategory converts the results of each category into prRecords
+func recordsByCategory(categories []string, results map[string][]*github.Issue) map[string][]prRecord {
+	records := make(map[string][]prRecord, len(categories))
+	for _, cat := range categories {
+		records[cat] = newPRRecords(results[cat])
+	}
+	return records
+}
+
+// createOutput creates the --output file, along with any missing parent directories
+func createOutput(path string) (*os.File, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create output directory: %w", err)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create output file: %w", err)
+	}
+	return f, nil
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v67/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPRRecord(t *testing.T) {
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	updatedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	issue := &github.Issue{
+		Number:            github.Int(42),
+		Title:             github.String("Add feature"),
+		HTMLURL:           github.String("https://github.com/owner/repo/pull/42"),
+		RepositoryURL:     github.String("https://api.github.com/repos/owner/repo"),
+		User:              &github.User{Login: github.String("author")},
+		AuthorAssociation: github.String("CONTRIBUTOR"),
+		CreatedAt:         &github.Timestamp{Time: createdAt},
+		UpdatedAt:         &github.Timestamp{Time: updatedAt},
+	}
+
+	assert.Equal(t, prRecord{
+		Number:            42,
+		Title:             "Add feature",
+		URL:               "https://github.com/owner/repo/pull/42",
+		Repo:              "owner/repo",
+		Author:            "author",
+		AuthorAssociation: "CONTRIBUTOR",
+		CreatedAt:         createdAt,
+		UpdatedAt:         updatedAt,
+	}, newPRRecord(issue))
+
+	// Missing optional fields produce zero values rather than panicking
+	assert.Equal(t, prRecord{Title: "PR", URL: "url", AuthorAssociation: associationUnknown}, newPRRecord(&github.Issue{
+		Title:   github.String("PR"),
+		HTMLURL: github.String("url"),
+	}))
+}
+
+func TestRunOutputFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "reports", "prs.csv")
+	updated := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+	pc := &PRChecker{
+		client:    &MockGitHubClient{response: createTestPRList(createTestPRWithNumber(1, "https://github.com/owner/repo/pull/1", updated))},
+		username:  "testuser",
+		formatter: NewDisplayFormatter(),
+		opts:      Options{Output: path, Format: formatCSV, Categories: []string{categoryCreated}},
+	}
+
+	// Missing parent directories are created and nothing is left for stdout
+	assert.NoError(t, runHosts([]*PRChecker{pc}))
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "category,number,repo,title,author,updated_at,url\n"+
+		"created,1,owner/repo,PR,,2024-01-02T15:04:05Z,https://github.com/owner/repo/pull/1\n", string(data))
+}
@@ -0,0 +1,30 @@
+package main
+
+import "github.com/google/go-github/v67/github"
+
+// exitPendingReviews is the exit status when --fail-if-pending finds review requests,
+// distinct from the status of 1 used for errors
+const exitPendingReviews = 2
+
+// countPending records the number of review requests listed in results
+func (pc *PRChecker) countPending(results map[string][]*github.Issue) {
+	pc.pending = len(results[categoryReviewer])
+}
+
+// pendingReviews returns the number of review requests listed by every checker's run
+func pendingReviews(checkers []*PRChecker) int {
+	total := 0
+	for _, pc := range checkers {
+		total += pc.pending
+	}
+	return total
+}
+
+// exitCode returns the exit status of a successful run: exitPendingReviews when
+// --fail-if-pending is set and review requests are listed, and zero otherwise
+func exitCode(failIfPending bool, pending int) int {
+	if failIfPending && pending > 0 {
+		return exitPendingReviews
+	}
+	return 0
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"io"
+	"testing"
+
+	"github.com/google/go-github/v67/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name          string
+		failIfPending bool
+		pending       int
+		want          int
+	}{
+		{name: "pending reviews", failIfPending: true, pending: 2, want: exitPendingReviews},
+		{name: "no pending reviews", failIfPending: true},
+		{name: "pending reviews without the flag", pending: 2},
+		{name: "nothing pending without the flag"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, exitCode(tt.failIfPending, tt.pending))
+		})
+	}
+}
+
+func TestPendingReviews(t *testing.T) {
+	first := &PRChecker{}
+	first.countPending(map[string][]*github.Issue{
+		categoryCreated:  {createTestPR("mine", "url1")},
+		categoryReviewer: {createTestPR("review", "url2"), createTestPR("another", "url3")},
+	})
+	second := &PRChecker{}
+	second.countPending(map[string][]*github.Issue{categoryReviewer: {createTestPR("review", "url4")}})
+	none := &PRChecker{}
+	none.countPending(map[string][]*github.Issue{categoryCreated: {createTestPR("mine", "url5")}})
+
+	assert.Equal(t, 2, first.pending)
+	assert.Equal(t, 3, pendingReviews([]*PRChecker{first, second, none}))
+	assert.Zero(t, pendingReviews([]*PRChecker{none}))
+}
+
+func TestRunCountsPending(t *testing.T) {
+	client := &MockGitHubClient{response: createTestPRList(createTestPRInRepo("PR", "owner/repo"))}
+	pc := &PRChecker{client: client, username: "testuser", out: io.Discard, opts: Options{
+		Categories: []string{categoryCreated, categoryReviewer},
+		Format:     formatJSON,
+		JSON:       true,
+	}}
+
+	assert.NoError(t, pc.Run())
+	assert.Equal(t, 1, pc.pending)
+	assert.Equal(t, exitPendingReviews, exitCode(true, pendingReviews([]*PRChecker{pc})))
+}
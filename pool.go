@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// forEachConcurrently calls fn for every item with at most concurrency calls running at
+// once. Every call runs to completion and their errors are joined. Once ctx is done no
+// further calls are started, and its error is included in the result.
+func forEachConcurrently[T any](ctx context.Context, items []T, concurrency int, fn func(context.Context, T) error) error {
+	sem := make(chan struct{}, max(concurrency, 1))
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+
+	for _, item := range items {
+		if ctx.Err() != nil {
+			break
+		}
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(item T) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if err := fn(ctx, item); err != nil {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+			}
+		}(item)
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForEachConcurrentlyLimit(t *testing.T) {
+	for _, concurrency := range []int{1, 3, 5} {
+		t.Run(fmt.Sprint(concurrency), func(t *testing.T) {
+			var inFlight, peak, calls atomic.Int32
+			items := make([]int, 20)
+
+			err := forEachConcurrently(context.Background(), items, concurrency, func(ctx context.Context, _ int) error {
+				n := inFlight.Add(1)
+				defer inFlight.Add(-1)
+				for {
+					p := peak.Load()
+					if n <= p || peak.CompareAndSwap(p, n) {
+						break
+					}
+				}
+				calls.Add(1)
+				time.Sleep(time.Millisecond)
+				return nil
+			})
+
+			assert.NoError(t, err)
+			assert.Equal(t, int32(20), calls.Load())
+			assert.LessOrEqual(t, peak.Load(), int32(concurrency))
+		})
+	}
+}
+
+func TestForEachConcurrentlyErrors(t *testing.T) {
+	errOdd := errors.New("odd item")
+	var mu sync.Mutex
+	var seen []int
+
+	err := forEachConcurrently(context.Background(), []int{1, 2, 3, 4, 5}, 2, func(ctx context.Context, item int) error {
+		mu.Lock()
+		seen = append(seen, item)
+		mu.Unlock()
+		if item%2 == 1 {
+			return fmt.Errorf("item %d: %w", item, errOdd)
+		}
+		return nil
+	})
+
+	// Every item runs and every error is reported
+	assert.ElementsMatch(t, []int{1, 2, 3, 4, 5}, seen)
+	assert.ErrorIs(t, err, errOdd)
+	for _, item := range []int{1, 3, 5} {
+		assert.ErrorContains(t, err, fmt.Sprintf("item %d", item))
+	}
+	assert.NotContains(t, err.Error(), "item 2")
+}
+
+func TestForEachConcurrentlyCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var calls atomic.Int32
+	err := forEachConcurrently(ctx, make([]int, 10), 1, func(ctx context.Context, _ int) error {
+		if calls.Add(1) == 2 {
+			cancel()
+		}
+		return nil
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, int32(2), calls.Load(), "pending items are not started")
+
+	// An already canceled context starts nothing
+	calls.Store(0)
+	err = forEachConcurrently(ctx, make([]int, 3), 2, func(ctx context.Context, _ int) error {
+		calls.Add(1)
+		return nil
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Zero(t, calls.Load())
+}
+
+func TestForEachConcurrentlyEmpty(t *testing.T) {
+	assert.NoError(t, forEachConcurrently(context.Background(), nil, 0, func(ctx context.Context, _ int) error {
+		return errors.New("not called")
+	}))
+}
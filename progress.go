@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/cli/go-gh/v2/pkg/term"
+)
+
+// progress shows a counter of completed enrichment requests on a terminal, so runs that
+// fetch details for many PRs do not appear to hang. All methods are safe on a nil progress.
+type progress struct {
+	w       io.Writer
+	enabled bool
+
+	mu    sync.Mutex
+	total int
+	done  int
+	shown bool // Whether a counter line is currently on screen
+}
+
+// newProgress creates a progress indicator writing to w, enabled only when requested and
+// w is a terminal
+func newProgress(w io.Writer, enabled bool) *progress {
+	return &progress{w: w, enabled: enabled && isTerminalWriter(w)}
+}
+
+// showsProgress reports whether a run draws the progress counter. It would be noise
+// around machine-readable results and in a shell prompt, which renders on every command.
+func showsProgress(opts Options) bool {
+	return !machineReadable(opts) && !opts.Prompt
+}
+
+// isTerminalWriter reports whether w is a file attached to a terminal
+func isTerminalWriter(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	return ok && term.IsTerminal(f)
+}
+
+// add registers n more requests that will be reported through step
+func (p *progress) add(n int) {
+	if p == nil || !p.enabled || n == 0 {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.total += n
+	p.render()
+}
+
+// step records one completed request
+func (p *progress) step() {
+	if p == nil || !p.enabled {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.done++
+	p.render()
+}
+
+// clear erases the counter line so subsequent output starts on a clean line
+func (p *progress) clear() {
+	if p == nil || !p.enabled {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.shown {
+		fmt.Fprint(p.w, "\r\033[K")
+		p.shown = false
+	}
+}
+
+// render redraws the counter line; the caller must hold mu
+func (p *progress) render() {
+	fmt.Fprintf(p.w, "\rFetching PR details... %d/%d", p.done, p.total)
+	p.shown = true
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProgressDisabledForNonTTY(t *testing.T) {
+	var buf bytes.Buffer
+	p := newProgress(&buf, true)
+	assert.False(t, p.enabled)
+
+	p.add(3)
+	p.step()
+	p.clear()
+	assert.Empty(t, buf.String())
+
+	// A regular file is not a terminal either
+	f, err := os.CreateTemp(t.TempDir(), "progress")
+	assert.NoError(t, err)
+	defer f.Close()
+	assert.False(t, newProgress(f, true).enabled)
+}
+
+func TestProgressRender(t *testing.T) {
+	var buf bytes.Buffer
+	p := &progress{w: &buf, enabled: true}
+
+	p.clear() // Nothing is on screen yet
+	assert.Empty(t, buf.String())
+
+	p.add(2)
+	p.step()
+	p.clear()
+	assert.Equal(t, "\rFetching PR details... 0/2\rFetching PR details... 1/2\r\033[K", buf.String())
+
+	// A nil progress is a no-op
+	var nilProgress *progress
+	nilProgress.add(1)
+	nilProgress.step()
+	nilProgress.clear()
+}
+
+func TestShowsProgress(t *testing.T) {
+	tests := []struct {
+		name string
+		opts Options
+		want bool
+	}{
+		{name: "table", opts: Options{Format: formatTable}, want: true},
+		{name: "html", opts: Options{Format: formatHTML}, want: true},
+		{name: "json", opts: Options{JSON: true}},
+		{name: "csv", opts: Options{Format: formatCSV}},
+		{name: "yaml", opts: Options{Format: formatYAML}},
+		{name: "ndjson", opts: Options{Format: formatNDJSON}},
+		{name: "tsv", opts: Options{Format: formatTSV}},
+		{name: "prompt", opts: Options{Format: formatTable, Prompt: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, showsProgress(tt.opts))
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/google/go-github/v67/github"
+)
+
+// Prompt mode configuration
+const (
+	promptCacheFileName = "prompt.json"   // File in the user cache directory holding the last count
+	defaultPromptTTL    = 5 * time.Minute // Age after which the cached count is refreshed
+	defaultStaleMarker  = "!"             // Appended to counts older than the TTL
+)
+
+// promptCache holds the last actionable count so shell prompts do not query GitHub on
+// every render
+type promptCache struct {
+	Host      string    `json:"host"` // Host the count was fetched from; empty for the default host
+	Count     int       `json:"count"`
+	FetchedAt time.Time `json:"fetched_at"`
+}
+
+// defaultPromptCachePath returns the location of the prompt cache in the user cache directory
+func defaultPromptCachePath() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "gh-myprs", promptCacheFileName), nil
+}
+
+// loadPromptCache reads the prompt cache at path, returning nil when it does not exist
+func loadPromptCache(path string) (*promptCache, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read prompt cache: %w", err)
+	}
+	var cache promptCache
+	if err := json.Unmarshal(data, &cache); err != nil {
+		return nil, fmt.Errorf("failed to parse prompt cache in %s: %w", path, err)
+	}
+	return &cache, nil
+}
+
+// save writes the cache atomically so an interrupted run cannot corrupt it
+func (c *promptCache) save(path string) error {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to save prompt cache: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to save prompt cache: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to save prompt cache: %w", err)
+	}
+	return nil
+}
+
+// isStale reports whether the cached count is older than ttl
+func (c *promptCache) isStale(now time.Time, ttl time.Duration) bool {
+	return now.Sub(c.FetchedAt) > ttl
+}
+
+// promptLabel formats the cached count for a shell prompt, appending marker when it is stale
+func promptLabel(cache *promptCache, now time.Time, ttl time.Duration, marker string) string {
+	label := strconv.Itoa(cache.Count)
+	if cache.isStale(now, ttl) {
+		label += marker
+	}
+	return label
+}
+
+// needsAction reports whether a created PR is waiting on its author, which is the case
+// when a reviewer's latest review requests changes
+func (pc *PRChecker) needsAction(issue *github.Issue) bool {
+	details := pc.detailsFor(issue)
+	return details != nil && details.decision == ReviewDecisionChangesRequested
+}
+
+// actionableCount returns the number of PRs needing the user's attention: every review
+// request plus the created PRs that need action
+func (pc *PRChecker) actionableCount(results map[string][]*github.Issue) int {
+	count := len(results[categoryReviewer])
+	for _, issue := range results[categoryCreated] {
+		if pc.needsAction(issue) {
+			count++
+		}
+	}
+	return count
+}
+
+// promptHost returns the host the prompt count is fetched from
+func promptHost(opts Options) string {
+	if len(opts.Hosts) == 1 {
+		return opts.Hosts[0]
+	}
+	return ""
+}
+
+// runPrompt prints the actionable count for a shell prompt, cached at path. A cached count
+// younger than the TTL is printed without querying GitHub; otherwise the count is
+// refreshed, falling back to the stale cached count with the stale marker when the
+// refresh fails.
+func runPrompt(opts Options, w io.Writer, path string, fetch func(Options) (int, error)) error {
+	cache, err := loadPromptCache(path)
+	if err != nil {
+		return err
+	}
+	host := promptHost(opts)
+	if cache != nil && cache.Host != host {
+		cache = nil
+	}
+
+	now := time.Now()
+	if cache != nil && !cache.isStale(now, opts.PromptTTL) {
+		_, err := fmt.Fprintln(w, promptLabel(cache, now, opts.PromptTTL, opts.StaleMarker))
+		return err
+	}
+
+	count, err := fetch(opts)
+	if err != nil {
+		if cache == nil {
+			return err
+		}
+		if opts.Verbose {
+			fmt.Fprintf(os.Stderr, "debug: showing cached count: %v\n", err)
+		}
+		_, err := fmt.Fprintln(w, promptLabel(cache, now, opts.PromptTTL, opts.StaleMarker))
+		return err
+	}
+
+	cache = &promptCache{Host: host, Count: count, FetchedAt: now}
+	if err := cache.save(path); err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, promptLabel(cache, now, opts.PromptTTL, opts.StaleMarker))
+	return err
+}
+
+// promptCategories returns the selected categories that actionableCount reads, so the
+// prompt does not search sections it never counts
+func promptCategories(categories []string) []string {
+	var counted []string
+	for _, category := range uniqueCategories(categories) {
+		if category == categoryCreated || category == categoryReviewer {
+			counted = append(counted, category)
+		}
+	}
+	return counted
+}
+
+// fetchActionableCount collects the counted sections of a run and counts the actionable PRs
+func fetchActionableCount(opts Options) (int, error) {
+	opts.Categories = promptCategories(opts.Categories)
+	if len(opts.Categories) == 0 {
+		return 0, nil
+	}
+	checkers, err := NewPRCheckers(opts)
+	if err != nil {
+		return 0, err
+	}
+	pc := checkers[0]
+	_, results, err := pc.collect(nil)
+	if err != nil {
+		return 0, err
+	}
+	return pc.actionableCount(results), nil
+}
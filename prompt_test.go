@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v67/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestActionableCount(t *testing.T) {
+	approved := createTestPR("Approved", "url1")
+	changes := createTestPR("Changes requested", "url2")
+	notEnriched := createTestPR("Not enriched", "url3")
+
+	pc := &PRChecker{}
+	pc.setDetails(approved, &prDetails{decision: ReviewDecisionApproved})
+	pc.setDetails(changes, &prDetails{decision: ReviewDecisionChangesRequested})
+
+	results := map[string][]*github.Issue{
+		categoryCreated:  {approved, changes, notEnriched},
+		categoryReviewer: {createTestPR("Review 1", "url4"), createTestPR("Review 2", "url5")},
+	}
+	assert.Equal(t, 3, pc.actionableCount(results))
+	assert.Equal(t, 0, pc.actionableCount(nil))
+}
+
+func TestPromptLabel(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		age  time.Duration
+		want string
+	}{
+		{name: "fresh", age: time.Minute, want: "5"},
+		{name: "exactly the ttl", age: 5 * time.Minute, want: "5"},
+		{name: "older than the ttl", age: 6 * time.Minute, want: "5!"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := &promptCache{Count: 5, FetchedAt: now.Add(-tt.age)}
+			assert.Equal(t, tt.want, promptLabel(cache, now, 5*time.Minute, "!"))
+		})
+	}
+}
+
+func TestRunPrompt(t *testing.T) {
+	opts := Options{PromptTTL: time.Minute, StaleMarker: "*"}
+	path := filepath.Join(t.TempDir(), promptCacheFileName)
+	fetches := 0
+	fetch := func(Options) (int, error) {
+		fetches++
+		return 4, nil
+	}
+	failing := func(Options) (int, error) { return 0, fmt.Errorf("offline") }
+
+	// Without a cache the count is fetched, printed and cached
+	var buf bytes.Buffer
+	assert.NoError(t, runPrompt(opts, &buf, path, fetch))
+	assert.Equal(t, "4\n", buf.String())
+	assert.Equal(t, 1, fetches)
+
+	// A fresh cache is printed without fetching
+	buf.Reset()
+	assert.NoError(t, runPrompt(opts, &buf, path, fetch))
+	assert.Equal(t, "4\n", buf.String())
+	assert.Equal(t, 1, fetches)
+
+	// A stale cache that cannot be refreshed is printed with the stale marker
+	stale := &promptCache{Count: 2, FetchedAt: time.Now().Add(-time.Hour)}
+	assert.NoError(t, stale.save(path))
+	buf.Reset()
+	assert.NoError(t, runPrompt(opts, &buf, path, failing))
+	assert.Equal(t, "2*\n", buf.String())
+
+	// A stale cache is refreshed when possible
+	buf.Reset()
+	assert.NoError(t, runPrompt(opts, &buf, path, fetch))
+	assert.Equal(t, "4\n", buf.String())
+	assert.Equal(t, 2, fetches)
+
+	// A cache for another host is ignored
+	buf.Reset()
+	opts.Hosts = []string{"ghe.example.com"}
+	assert.EqualError(t, runPrompt(opts, &buf, path, failing), "offline")
+	assert.Empty(t, buf.String())
+}
+
+func TestPromptCategories(t *testing.T) {
+	assert.Equal(t, []string{categoryCreated, categoryReviewer}, promptCategories(nil))
+	assert.Equal(t, []string{categoryReviewer}, promptCategories([]string{categoryAssigned, categoryReviewer, categoryMentioned}))
+	assert.Empty(t, promptCategories([]string{categoryAssigned}))
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/google/go-github/v67/github"
+)
+
+// issueToPRNumber returns the number of a search result that is a pull request,
+// reporting false for plain issues and results without a number
+func issueToPRNumber(issue *github.Issue) (int, bool) {
+	if issue == nil || !issue.IsPullRequest() || issue.Number == nil {
+		return 0, false
+	}
+	return *issue.Number, true
+}
+
+// keepPullRequests drops search results that are not pull requests, which an "is:pr"
+// search should never return, warning on w about each one so a malformed result does
+// not break the table
+func keepPullRequests(w io.Writer, category string, issues []*github.Issue) []*github.Issue {
+	filtered := make([]*github.Issue, 0, len(issues))
+	for _, issue := range issues {
+		if !issue.IsPullRequest() {
+			fmt.Fprintf(w, "warning: skipping %s result %q that is not a pull request\n", category, issue.GetHTMLURL())
+			continue
+		}
+		filtered = append(filtered, issue)
+	}
+	return filtered
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/google/go-github/v67/github"
+	"github.com/stretchr/testify/assert"
+)
+
+// createTestIssue returns a search result that is a plain issue rather than a PR
+func createTestIssue(title, url string) *github.Issue {
+	return &github.Issue{Number: github.Int(7), Title: github.String(title), HTMLURL: github.String(url)}
+}
+
+func TestIssueToPRNumber(t *testing.T) {
+	pr := createTestPR("PR", "https://github.com/owner/repo/pull/1")
+	pr.Number = github.Int(1)
+
+	tests := []struct {
+		name   string
+		issue  *github.Issue
+		want   int
+		wantOK bool
+	}{
+		{name: "pull request", issue: pr, want: 1, wantOK: true},
+		{name: "plain issue", issue: createTestIssue("Issue", "https://github.com/owner/repo/issues/7")},
+		{name: "pull request without a number", issue: createTestPR("PR", "https://github.com/owner/repo/pull/2")},
+		{name: "nil"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			number, ok := issueToPRNumber(tt.issue)
+			assert.Equal(t, tt.want, number)
+			assert.Equal(t, tt.wantOK, ok)
+		})
+	}
+}
+
+func TestKeepPullRequests(t *testing.T) {
+	first := createTestPR("first", "https://github.com/owner/repo/pull/1")
+	second := createTestPR("second", "https://github.com/owner/repo/pull/2")
+	issue := createTestIssue("issue", "https://github.com/owner/repo/issues/7")
+
+	var warnings bytes.Buffer
+	assert.Equal(t, []*github.Issue{first, second}, keepPullRequests(&warnings, categoryCreated, []*github.Issue{first, issue, second}))
+	assert.Equal(t, "warning: skipping created result \"https://github.com/owner/repo/issues/7\" that is not a pull request\n", warnings.String())
+
+	warnings.Reset()
+	assert.Empty(t, keepPullRequests(&warnings, categoryCreated, nil))
+	assert.Empty(t, warnings.String())
+}
+
+func TestSearchPullRequestsSkipsIssues(t *testing.T) {
+	pr := createTestPR("PR", "https://github.com/owner/repo/pull/1")
+	client := &MockGitHubClient{response: createTestPRList(pr, createTestIssue("issue", "https://github.com/owner/repo/issues/7"))}
+	pc := &PRChecker{client: client, username: "testuser"}
+
+	result, _, err := pc.searchPullRequests(context.Background(), categoryCreated)
+	assert.NoError(t, err)
+	assert.Len(t, result.Issues, 1)
+	assert.Equal(t, "PR", result.Issues[0].GetTitle())
+}
+
+func TestSectionHeaderCountsOnlyPullRequests(t *testing.T) {
+	list := createTestPRList(createTestPR("PR", "https://github.com/owner/repo/pull/1"), createTestIssue("issue", "https://github.com/owner/repo/issues/7"))
+	list.Total = github.Int(2)
+	client := &MockGitHubClient{response: list}
+	pc := &PRChecker{client: client, username: "testuser", opts: Options{Categories: []string{categoryCreated}}}
+
+	_, results, err := pc.collect(nil)
+	assert.NoError(t, err)
+	assert.Len(t, results[categoryCreated], 1)
+	header, err := pc.sectionHeader(categoryCreated)
+	assert.NoError(t, err)
+	assert.Contains(t, header, "testuser (1)")
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Rate limit headers sent with every GitHub API response
+const (
+	headerRateLimitLimit     = "X-RateLimit-Limit"
+	headerRateLimitRemaining = "X-RateLimit-Remaining"
+	headerRateLimitReset     = "X-RateLimit-Reset"
+	headerRetryAfter         = "Retry-After" // Sent with secondary rate limit errors
+
+	lowRateLimitPercent = 10 // Remaining quota, as a percentage of the limit, below which a warning is printed
+
+	jsonRateLimitKey = "rate_limit" // Top-level key holding the rate limit in JSON output
+)
+
+// rateLimit is the API quota reported by a response's X-RateLimit-* headers
+type rateLimit struct {
+	Limit     int       `json:"limit"`
+	Remaining int       `json:"remaining"`
+	Reset     time.Time `json:"reset"`
+}
+
+// rateLimitReporter is implemented by clients that remember the rate limit reported by
+// their most recent response
+type rateLimitReporter interface {
+	lastRateLimit() *rateLimit
+}
+
+// rateLimitOf returns the latest rate limit seen by client, or nil when it does not track
+// one or no response has reported it yet
+func rateLimitOf(client GitHubClient) *rateLimit {
+	if reporter, ok := client.(rateLimitReporter); ok {
+		return reporter.lastRateLimit()
+	}
+	return nil
+}
+
+// parseRateLimit reads the rate limit from response headers, reporting false when any of
+// them is missing or malformed
+func parseRateLimit(header http.Header) (*rateLimit, bool) {
+	limit, err := strconv.Atoi(header.Get(headerRateLimitLimit))
+	if err != nil {
+		return nil, false
+	}
+	remaining, err := strconv.Atoi(header.Get(headerRateLimitRemaining))
+	if err != nil {
+		return nil, false
+	}
+	reset, err := strconv.ParseInt(header.Get(headerRateLimitReset), 10, 64)
+	if err != nil {
+		return nil, false
+	}
+	return &rateLimit{Limit: limit, Remaining: remaining, Reset: time.Unix(reset, 0).UTC()}, true
+}
+
+// parseRetryAfter reads the time to retry at from a Retry-After header in either of its
+// forms, a number of seconds or an HTTP date, reporting false when it is missing or malformed
+func parseRetryAfter(header http.Header, now time.Time) (time.Time, bool) {
+	value := header.Get(headerRetryAfter)
+	if value == "" {
+		return time.Time{}, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return now.Add(time.Duration(seconds) * time.Second), true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return at, true
+	}
+	return time.Time{}, false
+}
+
+// isLow reports whether less than lowRateLimitPercent of the quota remains
+func (r *rateLimit) isLow() bool {
+	return r.Limit > 0 && r.Remaining*100 < r.Limit*lowRateLimitPercent
+}
+
+// warnRateLimit prints a warning to w when the client's latest response reported that
+// little of the rate limit remains
+func (pc *PRChecker) warnRateLimit(w io.Writer) {
+	rate := rateLimitOf(pc.client)
+	if rate == nil || !rate.isLow() {
+		return
+	}
+	prefix := ""
+	if pc.host != "" {
+		prefix = pc.host + ": "
+	}
+	reset := rate.Reset.In(pc.location()).Format("15:04 MST")
+	fmt.Fprintf(w, "%swarning: only %d of %d API requests remain until the rate limit resets at %s\n", prefix, rate.Remaining, rate.Limit, reset)
+}
+
+// String formats the rate limit as a single key=value status line
+func (r *rateLimit) String() string {
+	return fmt.Sprintf("rate limit: remaining=%d limit=%d reset=%s", r.Remaining, r.Limit, r.Reset.Format(time.RFC3339))
+}
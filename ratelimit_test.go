@@ -0,0 +1,219 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/google/go-github/v67/github"
+	"github.com/stretchr/testify/assert"
+)
+
+// stubTransport answers every request with a fixed status, headers and body
+type stubTransport struct {
+	status int
+	header http.Header
+	body   string
+}
+
+func (s *stubTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return &http.Response{
+		StatusCode: s.status,
+		Header:     s.header,
+		Body:       io.NopCloser(strings.NewReader(s.body)),
+		Request:    req,
+	}, nil
+}
+
+func rateLimitHeader(limit, remaining, reset string) http.Header {
+	header := http.Header{"Content-Type": {"application/json"}}
+	header.Set(headerRateLimitLimit, limit)
+	header.Set(headerRateLimitRemaining, remaining)
+	header.Set(headerRateLimitReset, reset)
+	return header
+}
+
+func TestParseRateLimit(t *testing.T) {
+	tests := []struct {
+		name   string
+		header http.Header
+		want   *rateLimit
+		wantOK bool
+	}{
+		{
+			name:   "all headers",
+			header: rateLimitHeader("5000", "4987", "1704067200"),
+			want:   &rateLimit{Limit: 5000, Remaining: 4987, Reset: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+			wantOK: true,
+		},
+		{
+			name:   "missing headers",
+			header: http.Header{},
+		},
+		{
+			name:   "malformed reset",
+			header: rateLimitHeader("5000", "4987", "soon"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseRateLimit(tt.header)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		value  string
+		want   time.Time
+		wantOK bool
+	}{
+		{name: "seconds", value: "30", want: now.Add(30 * time.Second), wantOK: true},
+		{name: "http date", value: "Mon, 01 Jan 2024 12:05:00 GMT", want: now.Add(5 * time.Minute), wantOK: true},
+		{name: "missing", value: ""},
+		{name: "negative seconds", value: "-1"},
+		{name: "malformed", value: "soon"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			if tt.value != "" {
+				header.Set(headerRetryAfter, tt.value)
+			}
+			got, ok := parseRetryAfter(header, now)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.True(t, tt.want.Equal(got))
+		})
+	}
+}
+
+// rateLimitClient reports a fixed rate limit
+type rateLimitClient struct {
+	MockGitHubClient
+	rate *rateLimit
+}
+
+func (c *rateLimitClient) lastRateLimit() *rateLimit {
+	return c.rate
+}
+
+func TestWarnRateLimit(t *testing.T) {
+	reset := time.Date(2024, 1, 1, 12, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		host string
+		rate *rateLimit
+		want string
+	}{
+		{name: "no rate limit"},
+		{name: "plenty remaining", rate: &rateLimit{Limit: 5000, Remaining: 500, Reset: reset}},
+		{
+			name: "low remaining",
+			rate: &rateLimit{Limit: 5000, Remaining: 499, Reset: reset},
+			want: "warning: only 499 of 5000 API requests remain until the rate limit resets at 12:30 UTC\n",
+		},
+		{
+			name: "low remaining on a host",
+			host: "ghe.example.com",
+			rate: &rateLimit{Limit: 30, Remaining: 0, Reset: reset},
+			want: "ghe.example.com: warning: only 0 of 30 API requests remain until the rate limit resets at 12:30 UTC\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pc := &PRChecker{client: &rateLimitClient{rate: tt.rate}, host: tt.host, opts: Options{Location: time.UTC}}
+			var buf bytes.Buffer
+			pc.warnRateLimit(&buf)
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestRESTClientRecordsRateLimit(t *testing.T) {
+	tests := []struct {
+		name      string
+		transport *stubTransport
+		wantErr   bool
+	}{
+		{
+			name:      "successful response",
+			transport: &stubTransport{status: http.StatusOK, header: rateLimitHeader("5000", "4987", "1704067200"), body: `{"login":"testuser"}`},
+		},
+		{
+			name:      "error response",
+			transport: &stubTransport{status: http.StatusForbidden, header: rateLimitHeader("5000", "4987", "1704067200"), body: `{"message":"forbidden"}`},
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rest, err := api.NewRESTClient(api.ClientOptions{Host: "github.com", AuthToken: "token", Transport: tt.transport})
+			assert.NoError(t, err)
+			client := &retryingClient{client: &githubRESTClient{client: rest}}
+			assert.Nil(t, rateLimitOf(client))
+
+			var user github.User
+			err = client.Get(context.Background(), "user", &user)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "testuser", user.GetLogin())
+			}
+
+			rate := rateLimitOf(client)
+			assert.Equal(t, "rate limit: remaining=4987 limit=5000 reset=2024-01-01T00:00:00Z", rate.String())
+
+			var buf bytes.Buffer
+			assert.NoError(t, writeJSON(&buf, []string{categoryCreated}, nil, map[string]interface{}{jsonRateLimitKey: rate}))
+			assert.Contains(t, buf.String(), `"rate_limit": {
+    "limit": 5000,
+    "remaining": 4987,
+    "reset": "2024-01-01T00:00:00Z"
+  }`)
+		})
+	}
+
+	// Clients that do not track rate limits report none
+	assert.Nil(t, rateLimitOf(&MockGitHubClient{}))
+}
+
+func TestRESTClientRequestIDInError(t *testing.T) {
+	header := http.Header{"Content-Type": {"application/json"}}
+	header.Set(headerRequestID, "ABCD:1234:5678EF:9ABCDE:65A1B2C3")
+	transport := &stubTransport{status: http.StatusBadGateway, header: header, body: `{"message":"Server Error"}`}
+
+	rest, err := api.NewRESTClient(api.ClientOptions{Host: "github.com", AuthToken: "token", Transport: transport})
+	assert.NoError(t, err)
+	client := &githubRESTClient{client: rest}
+
+	err = client.Get(context.Background(), "search/issues?q=author:testuser", &github.IssuesSearchResult{})
+	assert.ErrorContains(t, err, "(request ID: ABCD:1234:5678EF:9ABCDE:65A1B2C3)")
+
+	// The underlying HTTP error stays reachable for retry decisions
+	var httpErr *api.HTTPError
+	assert.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusBadGateway, httpErr.StatusCode)
+	assert.True(t, isRetryable(err))
+
+	// Errors without a request ID are returned unchanged
+	transport.header = http.Header{"Content-Type": {"application/json"}}
+	err = client.Get(context.Background(), "user", &github.User{})
+	assert.ErrorAs(t, err, &httpErr)
+	assert.NotContains(t, err.Error(), "request ID")
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Kinds of values replaced by --redact, each with its own placeholder sequence
+const (
+	redactUser   = "user"
+	redactOwner  = "org"
+	redactRepo   = "repo"
+	redactNumber = "number"
+)
+
+// redactor replaces usernames, repository owners and names, and PR numbers with
+// placeholders such as "user-a" and "org-1/repo-2#3". The same value always maps to the
+// same placeholder within a run, and each placeholder can be mapped back with reveal.
+// All methods are safe on a nil redactor, which leaves values unchanged.
+type redactor struct {
+	mu      sync.Mutex
+	forward map[string]string // kind and original value to placeholder
+	reverse map[string]string // kind and placeholder to original value
+	counts  map[string]int    // Placeholders issued per kind
+}
+
+// newRedactor creates a redactor with an empty mapping
+func newRedactor() *redactor {
+	return &redactor{
+		forward: make(map[string]string),
+		reverse: make(map[string]string),
+		counts:  make(map[string]int),
+	}
+}
+
+// placeholder returns the placeholder for a value of the given kind, issuing the next one
+// in sequence the first time the value is seen
+func (r *redactor) placeholder(kind, value string) string {
+	if r == nil || value == "" {
+		return value
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := kind + "\x00" + value
+	if p, ok := r.forward[key]; ok {
+		return p
+	}
+	r.counts[kind]++
+	var p string
+	switch kind {
+	case redactUser:
+		p = "user-" + letterSequence(r.counts[kind])
+	case redactNumber:
+		p = strconv.Itoa(r.counts[kind])
+	default:
+		p = fmt.Sprintf("%s-%d", kind, r.counts[kind])
+	}
+	r.forward[key] = p
+	r.reverse[kind+"\x00"+p] = value
+	return p
+}
+
+// reveal returns the original value behind a placeholder of the given kind
+func (r *redactor) reveal(kind, placeholder string) (string, bool) {
+	if r == nil {
+		return placeholder, true
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	value, ok := r.reverse[kind+"\x00"+placeholder]
+	return value, ok
+}
+
+// letterSequence returns the n-th label of the sequence a, b, ..., z, aa, ab, ...
+func letterSequence(n int) string {
+	var letters []byte
+	for ; n > 0; n = (n - 1) / 26 {
+		letters = append([]byte{byte('a' + (n-1)%26)}, letters...)
+	}
+	return string(letters)
+}
+
+// user redacts a login
+func (r *redactor) user(login string) string {
+	return r.placeholder(redactUser, login)
+}
+
+// repo redacts an "owner/name" repository, keeping its shape
+func (r *redactor) repo(fullName string) string {
+	owner, name, ok := strings.Cut(fullName, "/")
+	if r == nil || !ok {
+		return fullName
+	}
+	return r.placeholder(redactOwner, owner) + "/" + r.placeholder(redactRepo, name)
+}
+
+// number redacts a PR number, which is only unique within its repository
+func (r *redactor) number(repo string, number int) int {
+	if r == nil {
+		return number
+	}
+	n, _ := strconv.Atoi(r.placeholder(redactNumber, repo+"#"+strconv.Itoa(number)))
+	return n
+}
+
+// url redacts the owner, repository and number in a PR URL such as
+// https://github.com/owner/name/pull/123, leaving other URLs unchanged
+func (r *redactor) url(rawURL string) string {
+	if r == nil {
+		return rawURL
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return rawURL
+	}
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(segments) < 2 {
+		return rawURL
+	}
+
+	repo := segments[0] + "/" + segments[1]
+	if len(segments) >= 4 && (segments[2] == "pull" || segments[2] == "issues") {
+		if n, err := strconv.Atoi(segments[3]); err == nil {
+			segments[3] = strconv.Itoa(r.number(repo, n))
+		}
+	}
+	segments[0], segments[1], _ = strings.Cut(r.repo(repo), "/")
+	u.Path = "/" + strings.Join(segments, "/")
+	return u.String()
+}
+
+// record redacts the identifying fields of a prRecord
+func (r *redactor) record(record prRecord) prRecord {
+	if r == nil {
+		return record
+	}
+	record.Number = r.number(record.Repo, record.Number)
+	record.URL = r.url(record.URL)
+	record.Author = r.user(record.Author)
+	record.Repo = r.repo(record.Repo)
+	return record
+}
+
+// records redacts every record of each category in place
+func (r *redactor) records(byCategory map[string][]prRecord) {
+	for _, records := range byCategory {
+		for i := range records {
+			records[i] = r.record(records[i])
+		}
+	}
+}
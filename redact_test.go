@@ -0,0 +1,87 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-github/v67/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactorConsistent(t *testing.T) {
+	r := newRedactor()
+
+	assert.Equal(t, "user-a", r.user("octocat"))
+	assert.Equal(t, "user-b", r.user("hubot"))
+	assert.Equal(t, "user-a", r.user("octocat"))
+
+	assert.Equal(t, "org-1/repo-1", r.repo("octo-org/widgets"))
+	assert.Equal(t, "org-1/repo-2", r.repo("octo-org/gadgets"))
+	assert.Equal(t, "org-2/repo-1", r.repo("other/widgets"))
+
+	assert.Equal(t, 1, r.number("octo-org/widgets", 42))
+	assert.Equal(t, 2, r.number("octo-org/gadgets", 42))
+	assert.Equal(t, 1, r.number("octo-org/widgets", 42))
+
+	assert.Equal(t, "https://github.com/org-1/repo-1/pull/1", r.url("https://github.com/octo-org/widgets/pull/42"))
+	assert.Equal(t, "https://github.com/org-1/repo-2/pull/3", r.url("https://github.com/octo-org/gadgets/pull/7"))
+	assert.Equal(t, "not a url", r.url("not a url"))
+
+	// Every placeholder maps back to its original value
+	for kind, pairs := range map[string]map[string]string{
+		redactUser:   {"user-a": "octocat", "user-b": "hubot"},
+		redactOwner:  {"org-1": "octo-org", "org-2": "other"},
+		redactRepo:   {"repo-1": "widgets", "repo-2": "gadgets"},
+		redactNumber: {"1": "octo-org/widgets#42", "2": "octo-org/gadgets#42", "3": "octo-org/gadgets#7"},
+	} {
+		for placeholder, want := range pairs {
+			got, ok := r.reveal(kind, placeholder)
+			assert.True(t, ok)
+			assert.Equal(t, want, got)
+		}
+	}
+	_, ok := r.reveal(redactUser, "user-z")
+	assert.False(t, ok)
+}
+
+func TestLetterSequence(t *testing.T) {
+	assert.Equal(t, "a", letterSequence(1))
+	assert.Equal(t, "z", letterSequence(26))
+	assert.Equal(t, "aa", letterSequence(27))
+	assert.Equal(t, "ab", letterSequence(28))
+}
+
+func TestRedactedOutput(t *testing.T) {
+	issue := createTestPRInRepo("Fix bug", "octo-org/widgets")
+	issue.Number = github.Int(42)
+	issue.HTMLURL = github.String("https://github.com/octo-org/widgets/pull/42")
+	issue.User = &github.User{Login: github.String("hubot")}
+
+	pc := &PRChecker{username: "octocat", redact: newRedactor(), opts: Options{ShortURL: true}}
+
+	header, err := pc.sectionHeader(categoryCreated)
+	assert.NoError(t, err)
+	assert.Equal(t, "🔨 Pull Requests Created by user-a", header)
+	assert.Equal(t, "org-1/repo-1#1", pc.formatURL(issue, categoryCreated))
+
+	pc.opts.ShortURL = false
+	assert.Equal(t, "https://github.com/org-1/repo-1/pull/1", pc.formatURL(issue, categoryCreated))
+
+	record := pc.redact.record(newPRRecord(issue))
+	assert.Equal(t, 1, record.Number)
+	assert.Equal(t, "org-1/repo-1", record.Repo)
+	assert.Equal(t, "user-b", record.Author)
+	assert.Equal(t, "https://github.com/org-1/repo-1/pull/1", record.URL)
+
+	var buf bytes.Buffer
+	assert.NoError(t, writeHTML(&buf, []hostResult{{checker: pc, categories: []string{categoryCreated}, results: map[string][]*github.Issue{categoryCreated: {issue}}}}))
+	assert.NotContains(t, buf.String(), "octo")
+	assert.NotContains(t, buf.String(), "hubot")
+
+	// Without redaction values pass through unchanged
+	plain := &PRChecker{username: "octocat"}
+	header, err = plain.sectionHeader(categoryCreated)
+	assert.NoError(t, err)
+	assert.Equal(t, "🔨 Pull Requests Created by octocat", header)
+	assert.Equal(t, newPRRecord(issue), plain.redact.record(newPRRecord(issue)))
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v67/github"
+)
+
+// fetchHeadCommitTime returns when the head commit of a PR was committed, or the zero
+// time when the PR has no head commit
+func (pc *PRChecker) fetchHeadCommitTime(ctx context.Context, repo string, pr *github.PullRequest) (time.Time, error) {
+	sha := pr.GetHead().GetSHA()
+	if sha == "" {
+		return time.Time{}, nil
+	}
+
+	var commit github.RepositoryCommit
+	if err := pc.client.Get(ctx, fmt.Sprintf("repos/%s/commits/%s", repo, sha), &commit); err != nil {
+		return time.Time{}, err
+	}
+	return commit.GetCommit().GetCommitter().GetDate().Time, nil
+}
+
+// needsReReview reports whether username submitted a review of a PR and its head commit
+// was committed after their latest one. Pending reviews are not submitted yet and do not
+// count.
+func needsReReview(reviews []*github.PullRequestReview, headCommittedAt time.Time, username string) bool {
+	var latest time.Time
+	for _, review := range reviews {
+		if !strings.EqualFold(review.GetUser().GetLogin(), username) || review.GetState() == reviewStatePending {
+			continue
+		}
+		if submitted := review.GetSubmittedAt().Time; submitted.After(latest) {
+			latest = submitted
+		}
+	}
+	return !latest.IsZero() && headCommittedAt.After(latest)
+}
+
+// keepReReview keeps only the PRs with commits since the user's latest review
+func (pc *PRChecker) keepReReview(issues []*github.Issue) []*github.Issue {
+	var filtered []*github.Issue
+	for _, issue := range issues {
+		if details := pc.detailsFor(issue); details != nil && details.reReview {
+			filtered = append(filtered, issue)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v67/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNeedsReReview(t *testing.T) {
+	base := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		reviews   []*github.PullRequestReview
+		committed time.Time
+		want      bool
+	}{
+		{
+			name:      "commits after changes were requested",
+			reviews:   []*github.PullRequestReview{createTestReview("testuser", reviewStateChangesRequested, base)},
+			committed: base.Add(time.Hour),
+			want:      true,
+		},
+		{
+			name:      "no commits since the review",
+			reviews:   []*github.PullRequestReview{createTestReview("testuser", reviewStateChangesRequested, base)},
+			committed: base.Add(-time.Hour),
+		},
+		{
+			name: "later review covers the new commits",
+			reviews: []*github.PullRequestReview{
+				createTestReview("testuser", reviewStateChangesRequested, base),
+				createTestReview("testuser", reviewStateApproved, base.Add(2*time.Hour)),
+			},
+			committed: base.Add(time.Hour),
+		},
+		{
+			name:      "commented review counts, matched case-insensitively",
+			reviews:   []*github.PullRequestReview{createTestReview("TestUser", "COMMENTED", base)},
+			committed: base.Add(time.Minute),
+			want:      true,
+		},
+		{
+			name:      "only others reviewed",
+			reviews:   []*github.PullRequestReview{createTestReview("someone", reviewStateChangesRequested, base)},
+			committed: base.Add(time.Hour),
+		},
+		{
+			name:      "pending review is not submitted",
+			reviews:   []*github.PullRequestReview{createTestReview("testuser", reviewStatePending, base)},
+			committed: base.Add(time.Hour),
+		},
+		{
+			name:      "no reviews",
+			committed: base,
+		},
+		{
+			name:    "unknown head commit",
+			reviews: []*github.PullRequestReview{createTestReview("testuser", reviewStateChangesRequested, base)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, needsReReview(tt.reviews, tt.committed, "testuser"))
+		})
+	}
+}
+
+func TestFetchHeadCommitTime(t *testing.T) {
+	committed := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	client := &MockGitHubClient{responses: map[string]interface{}{
+		"repos/owner/repo/commits/abc123": &github.RepositoryCommit{Commit: &github.Commit{
+			Committer: &github.CommitAuthor{Date: &github.Timestamp{Time: committed}},
+		}},
+	}}
+	pc := &PRChecker{client: client}
+
+	pr := &github.PullRequest{Head: &github.PullRequestBranch{SHA: github.String("abc123")}}
+	got, err := pc.fetchHeadCommitTime(context.Background(), "owner/repo", pr)
+	assert.NoError(t, err)
+	assert.True(t, committed.Equal(got))
+}
+
+func TestEnrichReReview(t *testing.T) {
+	base := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	updated := createTestPRInRepo("updated", "owner/repo")
+	updated.Number = github.Int(1)
+	unchanged := createTestPRInRepo("unchanged", "owner/other")
+	unchanged.Number = github.Int(2)
+
+	client := &MockGitHubClient{responses: map[string]interface{}{
+		"repos/owner/repo/pulls/1/reviews?per_page=100": []*github.PullRequestReview{
+			createTestReview("testuser", reviewStateChangesRequested, base),
+		},
+		"repos/owner/repo/pulls/1":     &github.PullRequest{Head: &github.PullRequestBranch{SHA: github.String("new")}},
+		"repos/owner/repo/commits/new": &github.RepositoryCommit{Commit: &github.Commit{Committer: &github.CommitAuthor{Date: &github.Timestamp{Time: base.Add(time.Hour)}}}},
+		"repos/owner/other/pulls/2/reviews?per_page=100": []*github.PullRequestReview{
+			createTestReview("testuser", reviewStateChangesRequested, base),
+		},
+		"repos/owner/other/pulls/2":     &github.PullRequest{Head: &github.PullRequestBranch{SHA: github.String("old")}},
+		"repos/owner/other/commits/old": &github.RepositoryCommit{Commit: &github.Commit{Committer: &github.CommitAuthor{Date: &github.Timestamp{Time: base.Add(-time.Hour)}}}},
+	}}
+	pc := &PRChecker{client: client, username: "testuser"}
+
+	issues := []*github.Issue{updated, unchanged}
+	assert.True(t, pc.needsEnrichment(categoryReReview))
+	assert.NoError(t, pc.enrichIssues(context.Background(), categoryReReview, issues))
+	assert.Equal(t, []*github.Issue{updated}, pc.filterEnriched(categoryReReview, issues))
+}
+
+func TestReReviewCategory(t *testing.T) {
+	assert.NotContains(t, defaultCategories(), categoryReReview)
+
+	pc := &PRChecker{username: "testuser"}
+	query, err := pc.buildSearchQuery(categoryReReview)
+	assert.NoError(t, err)
+	assert.Equal(t, "is:open+is:pr+archived:false+reviewed-by:testuser+-author:testuser", query)
+
+	opts, err := parseOptions([]string{"--rereview"})
+	assert.NoError(t, err)
+	assert.Equal(t, append(defaultCategories(), categoryReReview), opts.Categories)
+
+	opts, err = parseOptions([]string{"--rereview", "--categories", "rereview,requested"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{categoryReReview, categoryReviewer}, opts.Categories)
+}
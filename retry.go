@@ -0,0 +1,188 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+// Retry configuration
+const (
+	defaultMaxRetries     = 10                     // Retries allowed across a whole run unless --max-retries is set
+	maxAttemptsPerRequest = 3                      // Attempts made for a single request, including the first, unless --retries is set
+	retryBaseDelay        = 500 * time.Millisecond // Delay before the first retry, doubled after each one
+
+	maxRateLimitWait     = time.Minute // Longest wait for a rate limit reset before giving up
+	rateLimitResetMargin = time.Second // Added to the reset time, which has one second precision
+)
+
+// retryBudget limits the total number of retries across every request in a run so a
+// GitHub incident cannot multiply per-request retries into a flood of calls
+type retryBudget struct {
+	mu        sync.Mutex
+	remaining int
+}
+
+// newRetryBudget creates a budget allowing up to n retries
+func newRetryBudget(n int) *retryBudget {
+	return &retryBudget{remaining: n}
+}
+
+// take consumes one retry from the budget, reporting false once it is exhausted
+func (b *retryBudget) take() bool {
+	if b == nil {
+		return false
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.remaining <= 0 {
+		return false
+	}
+	b.remaining--
+	return true
+}
+
+// retryingClient retries transient failures of the wrapped client within a shared budget
+type retryingClient struct {
+	client   GitHubClient
+	budget   *retryBudget
+	attempts int              // Attempts per request including the first, maxAttemptsPerRequest when zero
+	delay    time.Duration    // Delay before the first retry
+	now      func() time.Time // Clock used to wait for rate limit resets, time.Now when nil
+}
+
+func (c *retryingClient) Get(ctx context.Context, path string, response interface{}) error {
+	attempts, now := c.settings()
+	return retryGet(ctx, c.client, path, response, attempts, c.delay, c.budget, now)
+}
+
+// retry calls fn with the client's retry settings
+func (c *retryingClient) retry(ctx context.Context, fn func() error) error {
+	attempts, now := c.settings()
+	return withRetry(ctx, c.budget, attempts, c.delay, now, fn)
+}
+
+// settings returns the attempts per request and the clock, applying their defaults
+func (c *retryingClient) settings() (int, func() time.Time) {
+	now := c.now
+	if now == nil {
+		now = time.Now
+	}
+	attempts := c.attempts
+	if attempts == 0 {
+		attempts = maxAttemptsPerRequest
+	}
+	return attempts, now
+}
+
+// retryGet calls client.Get, retrying 5xx and network failures with exponential backoff
+// but never other 4xx errors. It makes at most attempts calls, drawing each retry from
+// budget and timing rate limit waits with now.
+func retryGet(ctx context.Context, client GitHubClient, path string, response interface{}, attempts int, delay time.Duration, budget *retryBudget, now func() time.Time) error {
+	return withRetry(ctx, budget, attempts, delay, now, func() error {
+		return client.Get(ctx, path, response)
+	})
+}
+
+func (c *retryingClient) lastRateLimit() *rateLimit {
+	return rateLimitOf(c.client)
+}
+
+// withRetry calls fn until it succeeds, fails permanently, reaches attempts or the budget
+// runs out. Rate limited requests wait for the limit to reset, while other failures back
+// off exponentially with jitter between attempts.
+func withRetry(ctx context.Context, budget *retryBudget, attempts int, delay time.Duration, now func() time.Time, fn func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || !isRetryable(err) || attempt >= attempts {
+			return err
+		}
+
+		wait := jitter(delay)
+		if reset, ok := rateLimitReset(err, now()); ok {
+			if wait, ok = rateLimitWait(ctx, reset, now()); !ok {
+				return err
+			}
+		}
+		if !budget.take() {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+		delay *= 2
+	}
+}
+
+// jitter returns a random delay between half and all of delay, so clients that failed
+// together do not retry in lockstep
+func jitter(delay time.Duration) time.Duration {
+	if delay <= 1 {
+		return delay
+	}
+	half := delay / 2
+	return half + rand.N(delay-half+1)
+}
+
+// rateLimitReset returns when a rate limited request may be retried, preferring the
+// Retry-After header of secondary rate limits over the primary reset time, and reporting
+// false for other errors
+func rateLimitReset(err error, now time.Time) (time.Time, bool) {
+	var httpErr *api.HTTPError
+	if !errors.As(err, &httpErr) || !isRateLimited(httpErr) {
+		return time.Time{}, false
+	}
+	if at, ok := parseRetryAfter(httpErr.Headers, now); ok {
+		return at, true
+	}
+	rate, ok := parseRateLimit(httpErr.Headers)
+	if !ok {
+		return time.Time{}, false
+	}
+	return rate.Reset, true
+}
+
+// rateLimitWait returns how long to wait from now for a rate limit reset, reporting false
+// when the wait would exceed maxRateLimitWait or outlast the context deadline
+func rateLimitWait(ctx context.Context, reset, now time.Time) (time.Duration, bool) {
+	wait := max(reset.Sub(now)+rateLimitResetMargin, 0)
+	if wait > maxRateLimitWait {
+		return 0, false
+	}
+	if deadline, ok := ctx.Deadline(); ok && now.Add(wait).After(deadline) {
+		return 0, false
+	}
+	return wait, true
+}
+
+// isRateLimited reports whether an HTTP error was caused by exhausting the primary rate
+// limit or by hitting a secondary rate limit, which sets Retry-After instead
+func isRateLimited(httpErr *api.HTTPError) bool {
+	limited := httpErr.StatusCode == http.StatusForbidden || httpErr.StatusCode == http.StatusTooManyRequests
+	return limited && (httpErr.Headers.Get(headerRateLimitRemaining) == "0" || httpErr.Headers.Get(headerRetryAfter) != "")
+}
+
+// isRetryable reports whether an error is a transient server or network failure, or a
+// primary rate limit that will reset
+func isRetryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var httpErr *api.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode >= http.StatusInternalServerError || isRateLimited(httpErr)
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
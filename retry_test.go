@@ -0,0 +1,275 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryingClient(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		budget    int
+		requests  int
+		wantCalls int
+	}{
+		{
+			name:      "success is not retried",
+			budget:    10,
+			requests:  1,
+			wantCalls: 1,
+		},
+		{
+			name:      "server error retried up to the per-request limit",
+			err:       &api.HTTPError{StatusCode: http.StatusBadGateway},
+			budget:    10,
+			requests:  1,
+			wantCalls: maxAttemptsPerRequest,
+		},
+		{
+			name:      "client error is not retried",
+			err:       &api.HTTPError{StatusCode: http.StatusNotFound},
+			budget:    10,
+			requests:  1,
+			wantCalls: 1,
+		},
+		{
+			name:      "exhausted budget stops retries for later requests",
+			err:       &api.HTTPError{StatusCode: http.StatusServiceUnavailable},
+			budget:    1,
+			requests:  3,
+			wantCalls: 4, // One retry for the first request, then a single attempt each
+		},
+		{
+			name:      "zero budget disables retries",
+			err:       &api.HTTPError{StatusCode: http.StatusInternalServerError},
+			budget:    0,
+			requests:  2,
+			wantCalls: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &MockGitHubClient{err: tt.err}
+			client := &retryingClient{client: mock, budget: newRetryBudget(tt.budget)}
+
+			for i := 0; i < tt.requests; i++ {
+				err := client.Get(context.Background(), "user", &struct{}{})
+				if tt.err == nil {
+					assert.NoError(t, err)
+				} else {
+					assert.ErrorIs(t, err, tt.err)
+				}
+			}
+			assert.Len(t, mock.requestedPaths(), tt.wantCalls)
+		})
+	}
+}
+
+// flakyClient fails its first failures requests with err before succeeding
+type flakyClient struct {
+	err      error
+	failures int
+	calls    int
+}
+
+func (c *flakyClient) Get(ctx context.Context, path string, response interface{}) error {
+	c.calls++
+	if c.calls <= c.failures {
+		return c.err
+	}
+	return nil
+}
+
+func TestRetryGet(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		wantErr   bool
+		wantCalls int
+	}{
+		{
+			name:      "server errors",
+			err:       &api.HTTPError{StatusCode: http.StatusServiceUnavailable},
+			wantCalls: 3,
+		},
+		{
+			name:      "network errors",
+			err:       &net.OpError{Op: "read", Err: errors.New("connection reset by peer")},
+			wantCalls: 3,
+		},
+		{
+			name:      "client errors are not retried",
+			err:       &api.HTTPError{StatusCode: http.StatusNotFound},
+			wantErr:   true,
+			wantCalls: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flaky := &flakyClient{err: tt.err, failures: 2}
+
+			err := retryGet(context.Background(), flaky, "user", &struct{}{}, 3, 0, newRetryBudget(10), time.Now)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantCalls, flaky.calls)
+		})
+	}
+}
+
+func TestRetryingClientAttempts(t *testing.T) {
+	tests := []struct {
+		name      string
+		attempts  int
+		wantErr   bool
+		wantCalls int
+	}{
+		{
+			name:      "default attempts outlast two failures",
+			wantCalls: 3,
+		},
+		{
+			name:      "too few attempts",
+			attempts:  2,
+			wantErr:   true,
+			wantCalls: 2,
+		},
+		{
+			name:      "single attempt disables retries",
+			attempts:  1,
+			wantErr:   true,
+			wantCalls: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flaky := &flakyClient{err: &api.HTTPError{StatusCode: http.StatusBadGateway}, failures: 2}
+			client := &retryingClient{client: flaky, budget: newRetryBudget(10), attempts: tt.attempts}
+
+			err := client.Get(context.Background(), "user", &struct{}{})
+			if tt.wantErr {
+				assert.ErrorIs(t, err, flaky.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantCalls, flaky.calls)
+		})
+	}
+}
+
+func TestJitter(t *testing.T) {
+	assert.Equal(t, time.Duration(0), jitter(0))
+	for i := 0; i < 100; i++ {
+		wait := jitter(retryBaseDelay)
+		assert.GreaterOrEqual(t, wait, retryBaseDelay/2)
+		assert.LessOrEqual(t, wait, retryBaseDelay)
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "server error", err: &api.HTTPError{StatusCode: http.StatusBadGateway}, want: true},
+		{name: "not found", err: &api.HTTPError{StatusCode: http.StatusNotFound}, want: false},
+		{name: "deadline exceeded", err: context.DeadlineExceeded, want: false},
+		{name: "other error", err: errors.New("boom"), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isRetryable(tt.err))
+		})
+	}
+}
+
+func rateLimitedError(remaining string, reset time.Time) *api.HTTPError {
+	return &api.HTTPError{
+		StatusCode: http.StatusForbidden,
+		Headers:    rateLimitHeader("5000", remaining, strconv.FormatInt(reset.Unix(), 10)),
+	}
+}
+
+func TestRateLimitWait(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		reset    time.Time
+		deadline time.Time
+		want     time.Duration
+		wantOK   bool
+	}{
+		{name: "waits until the reset", reset: now.Add(10 * time.Second), want: 10*time.Second + rateLimitResetMargin, wantOK: true},
+		{name: "reset already passed", reset: now.Add(-5 * time.Second), want: 0, wantOK: true},
+		{name: "reset too far away", reset: now.Add(maxRateLimitWait + time.Second)},
+		{name: "reset after the deadline", reset: now.Add(10 * time.Second), deadline: now.Add(5 * time.Second)},
+		{name: "reset before the deadline", reset: now.Add(2 * time.Second), deadline: now.Add(5 * time.Second), want: 2*time.Second + rateLimitResetMargin, wantOK: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if !tt.deadline.IsZero() {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithDeadline(ctx, tt.deadline)
+				defer cancel()
+			}
+
+			err := rateLimitedError("0", tt.reset)
+			assert.True(t, isRetryable(err))
+			reset, ok := rateLimitReset(err, now)
+			assert.True(t, ok)
+
+			got, ok := rateLimitWait(ctx, reset, now)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRetryingClientRateLimit(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	// A reset that has just passed is retried right away
+	mock := &MockGitHubClient{err: rateLimitedError("0", now.Add(-rateLimitResetMargin))}
+	client := &retryingClient{client: mock, budget: newRetryBudget(10), now: clock}
+	assert.Error(t, client.Get(context.Background(), "user", &struct{}{}))
+	assert.Len(t, mock.requestedPaths(), maxAttemptsPerRequest)
+
+	// A reset beyond the maximum wait gives up without retrying
+	mock = &MockGitHubClient{err: rateLimitedError("0", now.Add(time.Hour))}
+	client = &retryingClient{client: mock, budget: newRetryBudget(10), now: clock}
+	assert.Error(t, client.Get(context.Background(), "user", &struct{}{}))
+	assert.Len(t, mock.requestedPaths(), 1)
+	assert.Equal(t, 10, client.budget.remaining)
+
+	// A 403 with quota left is a permission error rather than a rate limit
+	assert.False(t, isRetryable(rateLimitedError("42", now)))
+
+	// A secondary rate limit waits for Retry-After rather than the primary reset
+	secondary := rateLimitedError("42", now.Add(time.Hour))
+	secondary.StatusCode = http.StatusTooManyRequests
+	secondary.Headers.Set(headerRetryAfter, "0")
+	assert.True(t, isRetryable(secondary))
+	reset, ok := rateLimitReset(secondary, now)
+	assert.True(t, ok)
+	assert.Equal(t, now, reset)
+}
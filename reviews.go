@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/google/go-github/v67/github"
+)
+
+// Review decision column shown with --reviews
+const (
+	reviewsLabel     = "Reviews" // Header of the review decision column
+	maxReviewsLength = 8         // Width of the review decision column
+)
+
+// ReviewDecision summarizes where a created PR stands with its reviewers
+type ReviewDecision string
+
+// Review decisions, named after GitHub's own PR review decisions
+const (
+	ReviewDecisionNone             ReviewDecision = ""                  // Nobody has reviewed the PR
+	ReviewDecisionApproved         ReviewDecision = "APPROVED"          // Approved with no outstanding changes request
+	ReviewDecisionChangesRequested ReviewDecision = "CHANGES_REQUESTED" // A reviewer's latest review requests changes
+	ReviewDecisionReviewRequired   ReviewDecision = "REVIEW_REQUIRED"   // Reviewed, but neither approved nor blocked
+)
+
+// reviewDecision aggregates each reviewer's latest review into a decision. A standing
+// changes request outweighs any number of approvals.
+func reviewDecision(reviews []*github.PullRequestReview) ReviewDecision {
+	counts := countReviews(reviews)
+	switch {
+	case counts.changesRequested > 0:
+		return ReviewDecisionChangesRequested
+	case counts.approvals > 0:
+		return ReviewDecisionApproved
+	}
+	for _, review := range reviews {
+		if review.GetState() != reviewStatePending {
+			return ReviewDecisionReviewRequired
+		}
+	}
+	return ReviewDecisionNone
+}
+
+// fetchReviewDecision fetches every review of a PR and aggregates them into a decision,
+// returning the reviews as well for the options that inspect them
+func (pc *PRChecker) fetchReviewDecision(ctx context.Context, repo string, number int) (ReviewDecision, []*github.PullRequestReview, error) {
+	path := fmt.Sprintf("repos/%s/pulls/%d/reviews?per_page=%d", repo, number, enrichPerPage)
+	reviews, err := listAll[*github.PullRequestReview](ctx, pc, path)
+	if err != nil {
+		return ReviewDecisionNone, nil, err
+	}
+	return reviewDecision(reviews), reviews, nil
+}
+
+// label returns the text shown for the decision in the table
+func (d ReviewDecision) label() string {
+	switch d {
+	case ReviewDecisionApproved:
+		return "Approved"
+	case ReviewDecisionChangesRequested:
+		return "Changes"
+	case ReviewDecisionReviewRequired:
+		return "Required"
+	default:
+		return "-"
+	}
+}
+
+// style returns the color the decision is shown in
+func (d ReviewDecision) style() *color.Color {
+	switch d {
+	case ReviewDecisionApproved:
+		return color.New(color.FgGreen)
+	case ReviewDecisionChangesRequested:
+		return color.New(color.FgRed)
+	case ReviewDecisionReviewRequired:
+		return color.New(color.FgYellow)
+	default:
+		return color.New(color.Faint)
+	}
+}
+
+// reviewDecisionOf returns the decision of an enriched created PR, or ReviewDecisionNone
+// for other sections and PRs without details
+func (pc *PRChecker) reviewDecisionOf(issue *github.Issue, category string) ReviewDecision {
+	if category != categoryCreated {
+		return ReviewDecisionNone
+	}
+	details := pc.detailsFor(issue)
+	if details == nil {
+		return ReviewDecisionNone
+	}
+	return details.decision
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v67/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReviewDecision(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		reviews []*github.PullRequestReview
+		want    ReviewDecision
+	}{
+		{
+			name: "no reviews",
+			want: ReviewDecisionNone,
+		},
+		{
+			name:    "only a pending draft review",
+			reviews: []*github.PullRequestReview{createTestReview("alice", reviewStatePending, base)},
+			want:    ReviewDecisionNone,
+		},
+		{
+			name:    "only comments",
+			reviews: []*github.PullRequestReview{createTestReview("alice", "COMMENTED", base)},
+			want:    ReviewDecisionReviewRequired,
+		},
+		{
+			name: "approved",
+			reviews: []*github.PullRequestReview{
+				createTestReview("alice", "COMMENTED", base),
+				createTestReview("bob", reviewStateApproved, base.Add(time.Hour)),
+			},
+			want: ReviewDecisionApproved,
+		},
+		{
+			name: "changes requested outweighs approvals",
+			reviews: []*github.PullRequestReview{
+				createTestReview("alice", reviewStateApproved, base),
+				createTestReview("bob", reviewStateChangesRequested, base.Add(time.Hour)),
+			},
+			want: ReviewDecisionChangesRequested,
+		},
+		{
+			name: "changes request superseded by approval",
+			reviews: []*github.PullRequestReview{
+				createTestReview("alice", reviewStateChangesRequested, base),
+				createTestReview("alice", reviewStateApproved, base.Add(time.Hour)),
+			},
+			want: ReviewDecisionApproved,
+		},
+		{
+			name: "dismissed approval",
+			reviews: []*github.PullRequestReview{
+				createTestReview("alice", reviewStateApproved, base),
+				createTestReview("alice", reviewStateDismissed, base.Add(time.Hour)),
+			},
+			want: ReviewDecisionReviewRequired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, reviewDecision(tt.reviews))
+		})
+	}
+}
+
+func TestReviewDecisionOf(t *testing.T) {
+	approved := createTestPR("approved", "url1")
+	notEnriched := createTestPR("not enriched", "url2")
+
+	pc := &PRChecker{opts: Options{Reviews: true}}
+	pc.setDetails(approved, &prDetails{decision: ReviewDecisionApproved})
+
+	assert.True(t, pc.needsEnrichment(categoryCreated))
+	assert.Equal(t, ReviewDecisionApproved, pc.reviewDecisionOf(approved, categoryCreated))
+	assert.Equal(t, ReviewDecisionNone, pc.reviewDecisionOf(approved, categoryReviewer))
+	assert.Equal(t, ReviewDecisionNone, pc.reviewDecisionOf(notEnriched, categoryCreated))
+	assert.Equal(t, "Approved", ReviewDecisionApproved.label())
+	assert.Equal(t, "-", ReviewDecisionNone.label())
+}
+
+func TestFetchReviewDecision(t *testing.T) {
+	submitted := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	reviews := []*github.PullRequestReview{
+		createTestReview("alice", reviewStateApproved, submitted),
+		createTestReview("bob", reviewStateChangesRequested, submitted.Add(time.Hour)),
+	}
+	client := &MockGitHubClient{responses: map[string]interface{}{
+		"repos/owner/repo/pulls/1/reviews?per_page=100": reviews,
+	}}
+	pc := &PRChecker{client: client, username: "testuser"}
+
+	decision, fetched, err := pc.fetchReviewDecision(context.Background(), "owner/repo", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, ReviewDecisionChangesRequested, decision)
+	assert.Len(t, fetched, 2)
+
+	client.err = errors.New("boom")
+	decision, _, err = pc.fetchReviewDecision(context.Background(), "owner/repo", 1)
+	assert.EqualError(t, err, "boom")
+	assert.Equal(t, ReviewDecisionNone, decision)
+}
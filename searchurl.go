@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/auth"
+)
+
+// commandURL is the subcommand that prints the web search URL for a category
+const commandURL = "url"
+
+// runURLCommand prints the browser-facing search URL equivalent to the API query for a
+// category. args are the arguments following the subcommand name.
+func runURLCommand(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: gh myprs %s <%s|%s> [flags]", commandURL, categoryCreated, categoryReviewer)
+	}
+	category := args[0]
+	if _, _, err := sectionTitle(category); err != nil {
+		return err
+	}
+
+	cfg, err := loadDefaultConfig()
+	if err != nil {
+		return err
+	}
+	opts, err := parseOptionsWithConfig(args[1:], cfg)
+	if err != nil {
+		return err
+	}
+	checker, err := NewPRChecker(opts)
+	if err != nil {
+		return err
+	}
+	query, err := checker.buildSearchQuery(category)
+	if err != nil {
+		return err
+	}
+
+	host, _ := auth.DefaultHost()
+	fmt.Println(webSearchURL(host, query))
+	return nil
+}
+
+// webSearchURL returns the GitHub web search URL for an API search query, whose
+// qualifiers are separated by "+" as an already-encoded space
+func webSearchURL(host, query string) string {
+	u := url.URL{Scheme: "https", Host: host, Path: "/search", RawQuery: "q=" + query + "&type=pulls"}
+	return u.String()
+}
+
+// decodeSearchQuery returns an API search query as it would be typed, with spaces
+// between its "+"-separated qualifiers
+func decodeSearchQuery(query string) string {
+	decoded, err := url.QueryUnescape(query)
+	if err != nil {
+		return strings.ReplaceAll(query, "+", " ")
+	}
+	return decoded
+}
+
+// printQueries writes the decoded search query of each selected category for
+// --print-query, prefixed with the host when several hosts are queried. The --team query
+// follows the requested category's own.
+func (pc *PRChecker) printQueries(w io.Writer) error {
+	for _, cat := range uniqueCategories(pc.opts.Categories) {
+		query, err := pc.buildSearchQuery(cat)
+		if err != nil {
+			return err
+		}
+		label := cat
+		if pc.host != "" {
+			label = pc.host + " " + cat
+		}
+		fmt.Fprintf(w, "%s: %s\n", label, decodeSearchQuery(query))
+
+		if pc.searchesTeam(cat) {
+			query, err := pc.buildTeamSearchQuery()
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(w, "%s: %s\n", label, decodeSearchQuery(query))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"bytes"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebSearchURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		category string
+		host     string
+		want     string
+		wantQ    string
+	}{
+		{
+			name:     "created",
+			category: categoryCreated,
+			host:     "github.com",
+			want:     "https://github.com/search?q=is:open+is:pr+archived:false+author:testuser+draft:false&type=pulls",
+			wantQ:    "is:open is:pr archived:false author:testuser draft:false",
+		},
+		{
+			name:     "requested on enterprise host",
+			category: categoryReviewer,
+			host:     "github.example.com",
+			want:     "https://github.example.com/search?q=is:open+is:pr+archived:false+user-review-requested:testuser&type=pulls",
+			wantQ:    "is:open is:pr archived:false user-review-requested:testuser",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pc := &PRChecker{username: "testuser"}
+			query, err := pc.buildSearchQuery(tt.category)
+			assert.NoError(t, err)
+
+			got := webSearchURL(tt.host, query)
+			assert.Equal(t, tt.want, got)
+
+			parsed, err := url.Parse(got)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantQ, parsed.Query().Get("q"))
+			assert.Equal(t, "pulls", parsed.Query().Get("type"))
+		})
+	}
+}
+
+func TestRunURLCommandArguments(t *testing.T) {
+	assert.ErrorContains(t, runURLCommand(nil), "usage:")
+	assert.ErrorContains(t, runURLCommand([]string{"merged"}), "unsupported PR category")
+}
+
+func TestDecodeSearchQuery(t *testing.T) {
+	assert.Equal(t, "is:open is:pr author:testuser", decodeSearchQuery("is:open+is:pr+author:testuser"))
+	assert.Equal(t, "is:open org:my-org", decodeSearchQuery("is:open+org%3Amy-org"))
+	assert.Equal(t, "is:open 100% done", decodeSearchQuery("is:open+100%+done")) // Invalid escapes only lose the "+"
+}
+
+func TestPrintQueries(t *testing.T) {
+	opts, err := parseOptions(nil)
+	assert.NoError(t, err)
+
+	var out bytes.Buffer
+	pc := &PRChecker{username: "testuser", opts: opts}
+	assert.NoError(t, pc.printQueries(&out))
+	assert.Equal(t, "created: is:open is:pr archived:false author:testuser draft:false\n"+
+		"requested: is:open is:pr archived:false user-review-requested:testuser\n"+
+		"assigned: is:open is:pr archived:false assignee:testuser\n"+
+		"mentioned: is:open is:pr archived:false mentions:testuser\n", out.String())
+
+	// Filters and hosts show up in the printed queries
+	out.Reset()
+	pc = &PRChecker{username: "testuser", host: "ghe.example.com", opts: Options{Categories: []string{categoryReviewer}, Org: "my-org"}}
+	assert.NoError(t, pc.printQueries(&out))
+	assert.Equal(t, "ghe.example.com requested: is:open is:pr archived:false user-review-requested:testuser org:my-org\n", out.String())
+
+	// Extra qualifiers are printed as they were given
+	out.Reset()
+	pc = &PRChecker{username: "testuser", opts: Options{Categories: []string{categoryAssigned}, QueryExtra: `label:"needs review"`}}
+	assert.NoError(t, pc.printQueries(&out))
+	assert.Equal(t, "assigned: is:open is:pr archived:false assignee:testuser label:\"needs review\"\n", out.String())
+
+	pc.opts.Repo = "invalid"
+	assert.Error(t, pc.printQueries(&out))
+}
+
+func TestRunPrintQuery(t *testing.T) {
+	client := &MockGitHubClient{response: createTestPRList(createTestPR("Test PR", "url"))}
+	var out bytes.Buffer
+	pc := &PRChecker{client: client, username: "testuser", formatter: NewDisplayFormatter(), opts: Options{PrintQuery: true}, out: &out}
+
+	assert.NoError(t, pc.Run())
+	assert.Empty(t, client.paths, "no search is sent")
+	assert.Empty(t, out.String(), "queries go to stderr")
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v67/github"
+)
+
+// seenFileName is the file in the user cache directory recording PRs already displayed
+const seenFileName = "seen.json"
+
+// seenStore records when each PR was last displayed, keyed by URL, so --new-only can
+// hide PRs that have not changed since
+type seenStore struct {
+	path string
+
+	mu   sync.Mutex
+	Seen map[string]time.Time `json:"seen"` // Updated time of each PR when it was last seen
+}
+
+// defaultSeenPath returns the location of the seen store in the user cache directory
+func defaultSeenPath() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "gh-myprs", seenFileName), nil
+}
+
+// loadSeenStore reads the seen store at path, returning an empty store when it does not exist
+func loadSeenStore(path string) (*seenStore, error) {
+	store := &seenStore{path: path, Seen: make(map[string]time.Time)}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read seen PRs: %w", err)
+	}
+	if err := json.Unmarshal(data, store); err != nil {
+		return nil, fmt.Errorf("failed to parse seen PRs in %s: %w", path, err)
+	}
+	if store.Seen == nil {
+		store.Seen = make(map[string]time.Time)
+	}
+	return store, nil
+}
+
+// isNew reports whether a PR has never been seen or was updated after it was last seen
+func (s *seenStore) isNew(issue *github.Issue) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	seen, ok := s.Seen[issue.GetHTMLURL()]
+	return !ok || issue.GetUpdatedAt().Time.After(seen)
+}
+
+// markSeen records the current updated time of each issue
+func (s *seenStore) markSeen(issues []*github.Issue) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, issue := range issues {
+		if url := issue.GetHTMLURL(); url != "" {
+			s.Seen[url] = issue.GetUpdatedAt().Time
+		}
+	}
+}
+
+// save writes the store atomically so an interrupted run cannot corrupt it
+func (s *seenStore) save() error {
+	s.mu.Lock()
+	data, err := json.Marshal(s)
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return fmt.Errorf("failed to save seen PRs: %w", err)
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to save seen PRs: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("failed to save seen PRs: %w", err)
+	}
+	return nil
+}
+
+// usesSeenStore reports whether the run reads or updates the seen store
+func (pc *PRChecker) usesSeenStore() bool {
+	return pc.opts.NewOnly || pc.opts.MarkAllSeen
+}
+
+// loadSeen loads the seen store from the cache directory unless one is already set
+func (pc *PRChecker) loadSeen() error {
+	if pc.seen != nil || !pc.usesSeenStore() {
+		return nil
+	}
+	path, err := defaultSeenPath()
+	if err != nil {
+		return fmt.Errorf("failed to locate seen PRs: %w", err)
+	}
+	pc.seen, err = loadSeenStore(path)
+	return err
+}
+
+// keepNew drops PRs that have not changed since they were last seen. Everything is
+// kept with --mark-all-seen, which records the full results instead.
+func (pc *PRChecker) keepNew(issues []*github.Issue) []*github.Issue {
+	if !pc.opts.NewOnly || pc.opts.MarkAllSeen || pc.seen == nil {
+		return issues
+	}
+	var filtered []*github.Issue
+	for _, issue := range issues {
+		if pc.seen.isNew(issue) {
+			filtered = append(filtered, issue)
+		}
+	}
+	return filtered
+}
+
+// recordSeen marks the displayed results as seen and saves the store
+func (pc *PRChecker) recordSeen(results ...map[string][]*github.Issue) error {
+	if pc.seen == nil {
+		return nil
+	}
+	for _, r := range results {
+		pc.seen.markSeen(flattenResults(r))
+	}
+	return pc.seen.save()
+}
+
+// countResults returns the number of PRs across all categories
+func countResults(results map[string][]*github.Issue) int {
+	total := 0
+	for _, issues := range results {
+		total += len(issues)
+	}
+	return total
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v67/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSeenStore(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	path := filepath.Join(t.TempDir(), "cache", seenFileName)
+
+	store, err := loadSeenStore(path)
+	assert.NoError(t, err)
+	assert.Empty(t, store.Seen)
+
+	seen := createTestPRWithNumber(1, "url1", base)
+	store.markSeen([]*github.Issue{seen})
+	assert.NoError(t, store.save())
+
+	reloaded, err := loadSeenStore(path)
+	assert.NoError(t, err)
+	assert.True(t, base.Equal(reloaded.Seen["url1"]))
+
+	// Marking again records the latest updated time
+	seen.UpdatedAt = &github.Timestamp{Time: base.Add(time.Hour)}
+	reloaded.markSeen([]*github.Issue{seen})
+	assert.True(t, base.Add(time.Hour).Equal(reloaded.Seen["url1"]))
+
+	assert.NoError(t, os.WriteFile(path, []byte("not json"), 0o600))
+	_, err = loadSeenStore(path)
+	assert.Error(t, err)
+}
+
+func TestKeepNew(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	unchanged := createTestPRWithNumber(1, "url1", base)
+	updated := createTestPRWithNumber(2, "url2", base.Add(time.Hour))
+	unseen := createTestPRWithNumber(3, "url3", base)
+
+	store := &seenStore{Seen: map[string]time.Time{"url1": base, "url2": base}}
+	issues := []*github.Issue{unchanged, updated, unseen}
+
+	pc := &PRChecker{opts: Options{NewOnly: true}, seen: store}
+	assert.Equal(t, []*github.Issue{updated, unseen}, pc.keepNew(issues))
+
+	pc.opts = Options{}
+	assert.Equal(t, issues, pc.keepNew(issues))
+
+	pc.opts = Options{NewOnly: true, MarkAllSeen: true}
+	assert.Equal(t, issues, pc.keepNew(issues))
+}
+
+func TestRunNewOnly(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	path := filepath.Join(t.TempDir(), seenFileName)
+	store, err := loadSeenStore(path)
+	assert.NoError(t, err)
+
+	client := &MockGitHubClient{response: createTestPRList(createTestPRWithNumber(1, "url1", base))}
+	newChecker := func(opts Options) *PRChecker {
+		return &PRChecker{client: client, username: "testuser", formatter: NewDisplayFormatter(), opts: opts, seen: store}
+	}
+
+	// The first run shows the PR and records it
+	pc := newChecker(Options{NewOnly: true, Categories: []string{categoryCreated}})
+	_, results, err := pc.collect(nil)
+	assert.NoError(t, err)
+	assert.Len(t, results[categoryCreated], 1)
+	assert.NoError(t, pc.recordSeen(results))
+
+	// Later runs hide it until it is updated
+	_, results, err = newChecker(Options{NewOnly: true, Categories: []string{categoryCreated}}).collect(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, results[categoryCreated])
+
+	reloaded, err := loadSeenStore(path)
+	assert.NoError(t, err)
+	assert.Contains(t, reloaded.Seen, "url1")
+}
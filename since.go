@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// sinceDateLayout is the --since layout for a calendar date
+const sinceDateLayout = "2006-01-02"
+
+// sinceFilter is a --since cutoff: an age measured back from the start of the run, or a
+// fixed date or time
+type sinceFilter struct {
+	age      time.Duration
+	date     time.Time
+	dateOnly bool // date was given without a time of day
+	value    string
+}
+
+func (s *sinceFilter) String() string {
+	return s.value
+}
+
+func (s *sinceFilter) Set(value string) error {
+	parsed, err := parseSince(value)
+	if err != nil {
+		return err
+	}
+	*s = parsed
+	return nil
+}
+
+// parseSince parses an age such as "24h" or "7d", a date such as "2024-01-01", or an
+// RFC 3339 time
+func parseSince(value string) (sinceFilter, error) {
+	if age, err := parseAge(value); err == nil {
+		if age <= 0 {
+			return sinceFilter{}, fmt.Errorf("age %q must be positive", value)
+		}
+		return sinceFilter{age: age, value: value}, nil
+	}
+	if date, err := time.Parse(sinceDateLayout, value); err == nil {
+		return sinceFilter{date: date, dateOnly: true, value: value}, nil
+	}
+	if date, err := time.Parse(time.RFC3339, value); err == nil {
+		return sinceFilter{date: date, value: value}, nil
+	}
+	return sinceFilter{}, fmt.Errorf("%q is not an age such as 24h or 7d, a date such as 2024-01-01, or an RFC 3339 time", value)
+}
+
+// isSet reports whether a cutoff was given
+func (s sinceFilter) isSet() bool {
+	return s.value != ""
+}
+
+// cutoff returns the time PRs must have been updated at or after. Ages are truncated to
+// the minute so repeated runs share cached searches.
+func (s sinceFilter) cutoff(now time.Time) time.Time {
+	if s.age > 0 {
+		return now.Add(-s.age).UTC().Truncate(time.Minute)
+	}
+	return s.date
+}
+
+// qualifier returns the "updated:>=" search qualifier for the cutoff, with the operator
+// URL-encoded
+func (s sinceFilter) qualifier(now time.Time) string {
+	value := s.cutoff(now).UTC().Format(time.RFC3339)
+	if s.dateOnly {
+		value = s.date.Format(sinceDateLayout)
+	}
+	return "updated:" + url.QueryEscape(">=") + value
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 30, 45, 0, time.FixedZone("JST", 9*60*60))
+	tests := []struct {
+		name          string
+		value         string
+		wantCutoff    time.Time
+		wantQualifier string
+		wantErr       bool
+	}{
+		{
+			name:          "hours",
+			value:         "24h",
+			wantCutoff:    time.Date(2024, 3, 9, 3, 30, 0, 0, time.UTC),
+			wantQualifier: "updated:%3E%3D2024-03-09T03:30:00Z",
+		},
+		{
+			name:          "days",
+			value:         "7d",
+			wantCutoff:    time.Date(2024, 3, 3, 3, 30, 0, 0, time.UTC),
+			wantQualifier: "updated:%3E%3D2024-03-03T03:30:00Z",
+		},
+		{
+			name:          "date",
+			value:         "2024-01-01",
+			wantCutoff:    time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			wantQualifier: "updated:%3E%3D2024-01-01",
+		},
+		{
+			name:          "RFC 3339 time in another zone",
+			value:         "2024-01-01T09:00:00+09:00",
+			wantCutoff:    time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			wantQualifier: "updated:%3E%3D2024-01-01T00:00:00Z",
+		},
+		{name: "zero age", value: "0h", wantErr: true},
+		{name: "negative age", value: "-24h", wantErr: true},
+		{name: "invalid date", value: "2024-13-01", wantErr: true},
+		{name: "unknown format", value: "yesterday", wantErr: true},
+		{name: "empty", value: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			since, err := parseSince(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, since.isSet())
+			assert.Equal(t, tt.value, since.String())
+			assert.True(t, tt.wantCutoff.Equal(since.cutoff(now)), "cutoff %s", since.cutoff(now))
+			assert.Equal(t, tt.wantQualifier, since.qualifier(now))
+		})
+	}
+}
+
+func TestSinceFlag(t *testing.T) {
+	opts, err := parseOptions([]string{"--since", "7d"})
+	assert.NoError(t, err)
+	assert.Equal(t, 7*24*time.Hour, opts.Since.age)
+
+	opts, err = parseOptions(nil)
+	assert.NoError(t, err)
+	assert.False(t, opts.Since.isSet())
+
+	_, err = parseOptions([]string{"--since", "soon"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v67/github"
+)
+
+// Timestamp fields selectable with --time-field
+const (
+	timeFieldUpdated = "updated"
+	timeFieldCreated = "created"
+)
+
+// Sort keys selectable with --sort; the default orders by --time-field
+const (
+	sortUrgency = "urgency"
+	sortUpdated = "updated"
+	sortCreated = "created"
+	sortTitle   = "title"
+)
+
+// Sort orders selectable with --order; the default depends on the sort key
+const (
+	orderAsc  = "asc"
+	orderDesc = "desc"
+)
+
+// orderIssues sorts a category's issues using the sort key and order selected in the options
+func (pc *PRChecker) orderIssues(category string, issues []*github.Issue, now time.Time) {
+	descending := pc.sortDescending()
+	switch pc.opts.Sort {
+	case sortUrgency:
+		scorer := pc.scoreUrgency
+		if scorer == nil {
+			scorer = defaultUrgencyScore
+		}
+		sortByScore(issues, func(issue *github.Issue) float64 {
+			return scorer(pc.urgencyInput(issue, category, now))
+		}, descending)
+	case sortTitle:
+		sortIssuesBy(issues, compareTitles, descending)
+	default:
+		sortIssues(issues, pc.sortTimeField(), descending)
+	}
+}
+
+// sortDescending reports whether issues are sorted in descending order. Without --order,
+// timestamps and urgency put the highest first while titles sort alphabetically.
+func (pc *PRChecker) sortDescending() bool {
+	if pc.opts.Order == "" {
+		return pc.opts.Sort != sortTitle
+	}
+	return pc.opts.Order == orderDesc
+}
+
+// sortTimeField returns the timestamp issues are sorted by: the --sort key when it names
+// one, and --time-field otherwise
+func (pc *PRChecker) sortTimeField() string {
+	switch pc.opts.Sort {
+	case sortUpdated:
+		return timeFieldUpdated
+	case sortCreated:
+		return timeFieldCreated
+	default:
+		return pc.opts.TimeField
+	}
+}
+
+// searchSortParams returns the query parameters asking the search API to order results
+// by the selected time field, so the page returned is the globally most recent one rather
+// than the most relevant. Sort keys the API cannot handle, such as urgency, are applied in
+// memory by orderIssues on top of this order.
+func (pc *PRChecker) searchSortParams() string {
+	return "&sort=" + pc.searchSortField() + "&order=" + pc.searchSortOrder()
+}
+
+// searchSortQualifier returns the sort:<field>-<order> qualifier used where the sort cannot
+// be passed as parameters, such as GraphQL searches
+func (pc *PRChecker) searchSortQualifier() string {
+	return "sort:" + pc.searchSortField() + "-" + pc.searchSortOrder()
+}
+
+// searchSortField returns the search sort key matching the time field issues are sorted by
+func (pc *PRChecker) searchSortField() string {
+	if pc.sortTimeField() == timeFieldCreated {
+		return timeFieldCreated
+	}
+	return timeFieldUpdated
+}
+
+// searchSortOrder returns the search order, ascending only when an ascending time sort
+// was asked for so a capped search returns the oldest PRs
+func (pc *PRChecker) searchSortOrder() string {
+	switch pc.opts.Sort {
+	case sortUrgency, sortTitle:
+		return orderDesc
+	}
+	if pc.sortDescending() {
+		return orderDesc
+	}
+	return orderAsc
+}
+
+// sortSectionsByCount returns the categories ordered by descending number of results,
+// breaking ties by registration order
+func sortSectionsByCount(categories []string, results map[string][]*github.Issue) []string {
+	categoryOrder := registeredCategories()
+	sorted := slices.Clone(categories)
+	slices.SortStableFunc(sorted, func(a, b string) int {
+		if c := cmp.Compare(len(results[b]), len(results[a])); c != 0 {
+			return c
+		}
+		return cmp.Compare(slices.Index(categoryOrder, a), slices.Index(categoryOrder, b))
+	})
+	return sorted
+}
+
+// parseSectionOrder parses the comma-separated categories of --order-sections
+func parseSectionOrder(value string) ([]string, error) {
+	var order []string
+	for _, cat := range strings.Split(value, ",") {
+		cat = strings.TrimSpace(cat)
+		if _, err := lookupCategory(cat); err != nil {
+			return nil, err
+		}
+		if slices.Contains(order, cat) {
+			return nil, fmt.Errorf("category %s is listed more than once", cat)
+		}
+		order = append(order, cat)
+	}
+	return order, nil
+}
+
+// orderSections returns the categories with those in order first, in that order,
+// followed by the rest in their original order. Categories in order that are not shown
+// are ignored.
+func orderSections(categories, order []string) []string {
+	sorted := make([]string, 0, len(categories))
+	for _, cat := range order {
+		if slices.Contains(categories, cat) {
+			sorted = append(sorted, cat)
+		}
+	}
+	for _, cat := range categories {
+		if !slices.Contains(order, cat) {
+			sorted = append(sorted, cat)
+		}
+	}
+	return sorted
+}
+
+// issueTime returns the timestamp of an issue selected by field, defaulting to the updated time
+func issueTime(issue *github.Issue, field string) time.Time {
+	if field == timeFieldCreated {
+		return issue.GetCreatedAt().Time
+	}
+	return issue.GetUpdatedAt().Time
+}
+
+// timeFieldLabel returns the column header for a time field
+func timeFieldLabel(field string) string {
+	if field == timeFieldCreated {
+		return "Created"
+	}
+	return "Updated"
+}
+
+// sortIssues orders issues by the selected time field, most recent first when descending.
+// Issues with identical timestamps are ordered by PR number and then URL so output is
+// deterministic.
+func sortIssues(issues []*github.Issue, timeField string, descending bool) {
+	sortIssuesBy(issues, func(a, b *github.Issue) int {
+		return issueTime(a, timeField).Compare(issueTime(b, timeField))
+	}, descending)
+}
+
+// sortByScore orders issues by score, breaking ties by PR number and then URL
+func sortByScore(issues []*github.Issue, score func(*github.Issue) float64, descending bool) {
+	scores := make(map[*github.Issue]float64, len(issues))
+	for _, issue := range issues {
+		scores[issue] = score(issue)
+	}
+	sortIssuesBy(issues, func(a, b *github.Issue) int {
+		return cmp.Compare(scores[a], scores[b])
+	}, descending)
+}
+
+// sortIssuesBy orders issues by compare, reversed when descending. Ties are always broken
+// by ascending PR number and then URL so output is deterministic in either order.
+func sortIssuesBy(issues []*github.Issue, compare func(a, b *github.Issue) int, descending bool) {
+	slices.SortStableFunc(issues, func(a, b *github.Issue) int {
+		c := compare(a, b)
+		if descending {
+			c = -c
+		}
+		if c != 0 {
+			return c
+		}
+		return compareTieBreak(a, b)
+	})
+}
+
+// compareTitles orders issues alphabetically by title, ignoring case
+func compareTitles(a, b *github.Issue) int {
+	return strings.Compare(strings.ToLower(a.GetTitle()), strings.ToLower(b.GetTitle()))
+}
+
+// compareTieBreak orders issues by PR number and then URL, both ascending
+func compareTieBreak(a, b *github.Issue) int {
+	if c := cmp.Compare(a.GetNumber(), b.GetNumber()); c != 0 {
+		return c
+	}
+	return strings.Compare(a.GetHTMLURL(), b.GetHTMLURL())
+}
@@ -0,0 +1,297 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v67/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestPRWithNumber(number int, url string, updatedAt time.Time) *github.Issue {
+	return &github.Issue{
+		Number:           github.Int(number),
+		Title:            github.String("PR"),
+		HTMLURL:          github.String(url),
+		PullRequestLinks: &github.PullRequestLinks{HTMLURL: github.String(url)},
+		UpdatedAt:        &github.Timestamp{Time: updatedAt},
+	}
+}
+
+func TestSortIssues(t *testing.T) {
+	older := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	newer := older.Add(time.Hour)
+
+	tests := []struct {
+		name   string
+		issues []*github.Issue
+		want   []string
+	}{
+		{
+			name: "most recently updated first",
+			issues: []*github.Issue{
+				createTestPRWithNumber(1, "url1", older),
+				createTestPRWithNumber(2, "url2", newer),
+			},
+			want: []string{"url2", "url1"},
+		},
+		{
+			name: "equal timestamps ordered by number then url",
+			issues: []*github.Issue{
+				createTestPRWithNumber(3, "https://github.com/b/repo/pull/3", newer),
+				createTestPRWithNumber(7, "https://github.com/a/repo/pull/7", newer),
+				createTestPRWithNumber(3, "https://github.com/a/repo/pull/3", newer),
+				createTestPRWithNumber(1, "https://github.com/c/repo/pull/1", older),
+				createTestPRWithNumber(2, "https://github.com/c/repo/pull/2", newer),
+			},
+			want: []string{
+				"https://github.com/c/repo/pull/2",
+				"https://github.com/a/repo/pull/3",
+				"https://github.com/b/repo/pull/3",
+				"https://github.com/a/repo/pull/7",
+				"https://github.com/c/repo/pull/1",
+			},
+		},
+		{
+			name: "missing timestamp sorts last",
+			issues: []*github.Issue{
+				{Number: github.Int(1), HTMLURL: github.String("url1")},
+				createTestPRWithNumber(2, "url2", older),
+			},
+			want: []string{"url2", "url1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Sorting the reversed input must yield the same order
+			reversed := make([]*github.Issue, len(tt.issues))
+			for i, issue := range tt.issues {
+				reversed[len(tt.issues)-1-i] = issue
+			}
+
+			for _, issues := range [][]*github.Issue{tt.issues, reversed} {
+				sortIssues(issues, timeFieldUpdated, true)
+				var got []string
+				for _, issue := range issues {
+					got = append(got, issue.GetHTMLURL())
+				}
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSortIssuesByCreated(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	oldButActive := createTestPRWithNumber(1, "url1", base.Add(time.Hour))
+	oldButActive.CreatedAt = &github.Timestamp{Time: base.AddDate(0, 0, -30)}
+	recent := createTestPRWithNumber(2, "url2", base)
+	recent.CreatedAt = &github.Timestamp{Time: base.AddDate(0, 0, -1)}
+
+	issues := []*github.Issue{oldButActive, recent}
+	sortIssues(issues, timeFieldCreated, true)
+	assert.Equal(t, []*github.Issue{recent, oldButActive}, issues)
+
+	sortIssues(issues, timeFieldUpdated, true)
+	assert.Equal(t, []*github.Issue{oldButActive, recent}, issues)
+}
+
+func TestOrderIssuesBySortKey(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	newPR := func(number int, title string, created, updated time.Time) *github.Issue {
+		issue := createTestPRWithNumber(number, fmt.Sprintf("url%d", number), updated)
+		issue.Title = github.String(title)
+		issue.CreatedAt = &github.Timestamp{Time: created}
+		return issue
+	}
+	// 1 and 2 share both timestamps, so only the tie-break orders them
+	issues := []*github.Issue{
+		newPR(2, "beta", base, base.Add(2*time.Hour)),
+		newPR(3, "Alpha", base.Add(time.Hour), base.Add(time.Hour)),
+		newPR(1, "gamma", base, base.Add(2*time.Hour)),
+		newPR(4, "alpha", base.Add(-time.Hour), base),
+	}
+
+	tests := []struct {
+		name string
+		sort string
+		ord  string
+		want []int
+	}{
+		{name: "default is most recently updated first", want: []int{1, 2, 3, 4}},
+		{name: "updated ascending", sort: sortUpdated, ord: orderAsc, want: []int{4, 3, 1, 2}},
+		{name: "created descending", sort: sortCreated, want: []int{3, 1, 2, 4}},
+		{name: "created ascending", sort: sortCreated, ord: orderAsc, want: []int{4, 1, 2, 3}},
+		{name: "title defaults to alphabetical ignoring case", sort: sortTitle, want: []int{3, 4, 2, 1}},
+		{name: "title descending", sort: sortTitle, ord: orderDesc, want: []int{1, 2, 3, 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pc := &PRChecker{opts: Options{TimeField: timeFieldUpdated, Sort: tt.sort, Order: tt.ord}}
+			// Every starting order must yield the same result
+			for _, input := range [][]*github.Issue{slices.Clone(issues), reversedIssues(issues)} {
+				pc.orderIssues(categoryCreated, input, base)
+				var got []int
+				for _, issue := range input {
+					got = append(got, issue.GetNumber())
+				}
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func reversedIssues(issues []*github.Issue) []*github.Issue {
+	reversed := slices.Clone(issues)
+	slices.Reverse(reversed)
+	return reversed
+}
+
+func TestFetchPullRequestsServerSort(t *testing.T) {
+	tests := []struct {
+		name string
+		opts Options
+		want string
+	}{
+		{
+			name: "default sorts by updated",
+			want: "search/issues?q=is:open+is:pr+archived:false+author:testuser+draft:false&sort=updated&order=desc&per_page=100",
+		},
+		{
+			name: "created time field",
+			opts: Options{TimeField: timeFieldCreated},
+			want: "search/issues?q=is:open+is:pr+archived:false+author:testuser+draft:false&sort=created&order=desc&per_page=100",
+		},
+		{
+			name: "ascending created sort",
+			opts: Options{TimeField: timeFieldUpdated, Sort: sortCreated, Order: orderAsc},
+			want: "search/issues?q=is:open+is:pr+archived:false+author:testuser+draft:false&sort=created&order=asc&per_page=100",
+		},
+		{
+			name: "title sort fetches the most recent page",
+			opts: Options{TimeField: timeFieldUpdated, Sort: sortTitle, Order: orderAsc},
+			want: "search/issues?q=is:open+is:pr+archived:false+author:testuser+draft:false&sort=updated&order=desc&per_page=100",
+		},
+		{
+			name: "urgency still fetches the most recent page",
+			opts: Options{TimeField: timeFieldUpdated, Sort: sortUrgency},
+			want: "search/issues?q=is:open+is:pr+archived:false+author:testuser+draft:false&sort=updated&order=desc&per_page=100",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &MockGitHubClient{}
+			pc := &PRChecker{client: client, username: "testuser", opts: tt.opts}
+
+			_, _, err := pc.searchPullRequests(context.Background(), categoryCreated)
+			assert.NoError(t, err)
+			assert.Equal(t, []string{tt.want}, client.requestedPaths())
+		})
+	}
+}
+
+func TestSortSectionsByCount(t *testing.T) {
+	one := []*github.Issue{createTestPR("a", "url1")}
+	two := []*github.Issue{createTestPR("b", "url2"), createTestPR("c", "url3")}
+
+	tests := []struct {
+		name       string
+		categories []string
+		results    map[string][]*github.Issue
+		want       []string
+	}{
+		{
+			name:       "busiest section first",
+			categories: []string{categoryCreated, categoryReviewer},
+			results:    map[string][]*github.Issue{categoryCreated: one, categoryReviewer: two},
+			want:       []string{categoryReviewer, categoryCreated},
+		},
+		{
+			name:       "already in order",
+			categories: []string{categoryCreated, categoryReviewer},
+			results:    map[string][]*github.Issue{categoryCreated: two, categoryReviewer: one},
+			want:       []string{categoryCreated, categoryReviewer},
+		},
+		{
+			name:       "ties use the fixed category order",
+			categories: []string{categoryReviewer, categoryCreated},
+			results:    map[string][]*github.Issue{categoryCreated: one, categoryReviewer: one},
+			want:       []string{categoryCreated, categoryReviewer},
+		},
+		{
+			name:       "empty sections last",
+			categories: []string{categoryCreated, categoryReviewer},
+			results:    map[string][]*github.Issue{categoryReviewer: one},
+			want:       []string{categoryReviewer, categoryCreated},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := slices.Clone(tt.categories)
+			assert.Equal(t, tt.want, sortSectionsByCount(tt.categories, tt.results))
+			assert.Equal(t, original, tt.categories)
+		})
+	}
+}
+
+func TestOrderSections(t *testing.T) {
+	all := []string{categoryCreated, categoryReviewer, categoryAssigned, categoryMentioned}
+	tests := []struct {
+		name       string
+		categories []string
+		order      []string
+		want       []string
+	}{
+		{
+			name:       "listed sections first",
+			categories: all,
+			order:      []string{categoryReviewer, categoryCreated},
+			want:       []string{categoryReviewer, categoryCreated, categoryAssigned, categoryMentioned},
+		},
+		{
+			name:       "rest keep their order",
+			categories: []string{categoryMentioned, categoryCreated, categoryAssigned},
+			order:      []string{categoryAssigned},
+			want:       []string{categoryAssigned, categoryMentioned, categoryCreated},
+		},
+		{
+			name:       "sections not shown are ignored",
+			categories: []string{categoryCreated, categoryAssigned},
+			order:      []string{categoryReviewer, categoryAssigned},
+			want:       []string{categoryAssigned, categoryCreated},
+		},
+		{
+			name:       "no order",
+			categories: all,
+			want:       all,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := slices.Clone(tt.categories)
+			assert.Equal(t, tt.want, orderSections(tt.categories, tt.order))
+			assert.Equal(t, original, tt.categories)
+		})
+	}
+}
+
+func TestParseSectionOrder(t *testing.T) {
+	order, err := parseSectionOrder("requested, created")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{categoryReviewer, categoryCreated}, order)
+
+	_, err = parseSectionOrder("requested,unknown")
+	assert.Error(t, err)
+	_, err = parseSectionOrder("requested,requested")
+	assert.ErrorContains(t, err, "more than once")
+	_, err = parseSectionOrder("requested,")
+	assert.Error(t, err)
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v67/github"
+)
+
+// iconStale is shown before the titles of PRs not updated within --stale
+const iconStale = "⏳"
+
+// ageValue is a flag.Value for durations that also accepts whole days, such as "7d"
+type ageValue time.Duration
+
+func (a *ageValue) String() string {
+	return time.Duration(*a).String()
+}
+
+func (a *ageValue) Set(value string) error {
+	d, err := parseAge(value)
+	if err != nil {
+		return err
+	}
+	*a = ageValue(d)
+	return nil
+}
+
+// parseAge parses a duration such as "36h", or a number of days such as "7d"
+func parseAge(value string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, fmt.Errorf("invalid number of days %q", value)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	return time.ParseDuration(value)
+}
+
+// isStale reports whether a PR last updated at updatedAt has gone longer than threshold
+// without an update. A threshold of zero or less never marks a PR as stale.
+func isStale(updatedAt time.Time, threshold time.Duration, now time.Time) bool {
+	return threshold > 0 && now.Sub(updatedAt) > threshold
+}
+
+// isStaleIssue reports whether a PR's time field, as shown in the table, is older than --stale
+func (pc *PRChecker) isStaleIssue(issue *github.Issue, now time.Time) bool {
+	return isStale(issueTime(issue, pc.opts.TimeField), pc.opts.Stale, now)
+}
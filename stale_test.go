@@ -0,0 +1,78 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v67/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsStale(t *testing.T) {
+	now := time.Date(2024, 1, 8, 12, 0, 0, 0, time.UTC)
+	week := 7 * 24 * time.Hour
+
+	tests := []struct {
+		name      string
+		updatedAt time.Time
+		threshold time.Duration
+		want      bool
+	}{
+		{name: "recently updated", updatedAt: now.Add(-time.Hour), threshold: week, want: false},
+		{name: "exactly at the threshold", updatedAt: now.Add(-week), threshold: week, want: false},
+		{name: "just past the threshold", updatedAt: now.Add(-week - time.Second), threshold: week, want: true},
+		{name: "long ago", updatedAt: now.AddDate(-1, 0, 0), threshold: week, want: true},
+		{name: "updated in the future", updatedAt: now.Add(time.Hour), threshold: week, want: false},
+		{name: "disabled", updatedAt: now.AddDate(-1, 0, 0), threshold: 0, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isStale(tt.updatedAt, tt.threshold, now))
+		})
+	}
+}
+
+func TestParseAge(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{value: "7d", want: 7 * 24 * time.Hour},
+		{value: "0d", want: 0},
+		{value: "36h", want: 36 * time.Hour},
+		{value: "1.5d", wantErr: true},
+		{value: "d", wantErr: true},
+		{value: "week", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseAge(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFormatTitleStale(t *testing.T) {
+	now := time.Date(2024, 1, 8, 12, 0, 0, 0, time.UTC)
+	quiet := createTestPR("Quiet PR", "url1")
+	quiet.UpdatedAt = &github.Timestamp{Time: now.AddDate(0, 0, -10)}
+	active := createTestPR("Active PR", "url2")
+	active.UpdatedAt = &github.Timestamp{Time: now.Add(-time.Hour)}
+
+	pc := &PRChecker{opts: Options{Stale: 7 * 24 * time.Hour}, clock: func() time.Time { return now }}
+	assert.Equal(t, iconStale+" Quiet PR", pc.formatTitle(quiet, categoryCreated))
+	assert.Equal(t, "Active PR", pc.formatTitle(active, categoryCreated))
+
+	// With --time-field created, staleness follows the creation time shown in the table
+	active.CreatedAt = &github.Timestamp{Time: now.AddDate(0, 0, -10)}
+	pc.opts.TimeField = timeFieldCreated
+	assert.Equal(t, iconStale+" Active PR", pc.formatTitle(active, categoryCreated))
+}
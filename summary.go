@@ -0,0 +1,37 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/v67/github"
+)
+
+// summaryLabel returns the word describing a category's PRs in the summary line,
+// falling back to the category name for categories without one
+func summaryLabel(category string) string {
+	c, err := lookupCategory(category)
+	if err != nil || c.Summary == "" {
+		return category
+	}
+	return c.Summary
+}
+
+// summaryLine tallies the PRs listed in each category, such as
+// "Total: 5 created, 12 to review, 2 assigned"
+func summaryLine(categories []string, counts map[string]int) string {
+	parts := make([]string, 0, len(categories))
+	for _, cat := range categories {
+		parts = append(parts, fmt.Sprintf("%d %s", counts[cat], summaryLabel(cat)))
+	}
+	return "Total: " + strings.Join(parts, ", ")
+}
+
+// printSummary writes the summary line after the table sections
+func (pc *PRChecker) printSummary(categories []string, results map[string][]*github.Issue) {
+	counts := make(map[string]int, len(categories))
+	for _, cat := range categories {
+		counts[cat] = len(results[cat])
+	}
+	pc.formatter.headerStyle.Fprintln(pc.writer(), summaryLine(categories, counts))
+}
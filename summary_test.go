@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/google/go-github/v67/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummaryLine(t *testing.T) {
+	tests := []struct {
+		name       string
+		categories []string
+		counts     map[string]int
+		want       string
+	}{
+		{
+			name:       "default categories",
+			categories: []string{categoryCreated, categoryReviewer, categoryAssigned, categoryMentioned},
+			counts:     map[string]int{categoryCreated: 5, categoryReviewer: 12, categoryAssigned: 2},
+			want:       "Total: 5 created, 12 to review, 2 assigned, 0 mentioning you",
+		},
+		{
+			name:       "selected categories in order",
+			categories: []string{categoryReviewer, categoryCreated},
+			counts:     map[string]int{categoryCreated: 1, categoryReviewer: 3},
+			want:       "Total: 3 to review, 1 created",
+		},
+		{
+			name:       "category without a summary label",
+			categories: []string{"unregistered"},
+			counts:     map[string]int{"unregistered": 4},
+			want:       "Total: 4 unregistered",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, summaryLine(tt.categories, tt.counts))
+		})
+	}
+}
+
+func TestPrintSummary(t *testing.T) {
+	noColor := color.NoColor
+	defer func() { color.NoColor = noColor }()
+
+	results := map[string][]*github.Issue{
+		categoryCreated:  {createTestPR("one", "url1"), createTestPR("two", "url2")},
+		categoryReviewer: {createTestPR("three", "url3")},
+	}
+	categories := []string{categoryCreated, categoryReviewer, categoryAssigned}
+
+	var out bytes.Buffer
+	pc := &PRChecker{formatter: NewDisplayFormatter(), out: &out}
+	color.NoColor = true
+	pc.printSummary(categories, results)
+	assert.Equal(t, "Total: 2 created, 1 to review, 0 assigned\n", out.String())
+
+	out.Reset()
+	color.NoColor = false
+	pc.printSummary(categories, results)
+	assert.Contains(t, out.String(), "\x1b[")
+	assert.Contains(t, out.String(), "Total: 2 created, 1 to review, 0 assigned")
+}
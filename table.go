@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// Table columns whose alignment can be set with --align
+const (
+	columnNumber   = "number"
+	columnTitle    = "title"
+	columnRepo     = "repo"
+	columnAuthor   = "author"
+	columnTime     = "time"
+	columnReviews  = "reviews"
+	columnChecks   = "checks"
+	columnThreads  = "threads"
+	columnComments = "comments"
+	columnURL      = "url"
+)
+
+// Column alignments
+const (
+	alignLeft  = "left"
+	alignRight = "right"
+)
+
+// defaultAlignments holds the columns not aligned left unless set with --align
+var defaultAlignments = map[string]string{
+	columnNumber:   alignRight, // Numbers line up by their last digit
+	columnComments: alignRight,
+}
+
+// maxColumnPadding keeps the URL column wide enough to show a truncated link
+const maxColumnPadding = 8
+
+// parseAlignments parses a comma-separated list of column=alignment pairs, such as
+// "time=right", into a map from column to alignment
+func parseAlignments(value string) (map[string]string, error) {
+	alignments := make(map[string]string)
+	if value == "" {
+		return alignments, nil
+	}
+	for _, pair := range strings.Split(value, ",") {
+		column, align, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok {
+			return nil, fmt.Errorf("%q is not a column=alignment pair", pair)
+		}
+		switch column {
+		case columnNumber, columnTitle, columnRepo, columnAuthor, columnTime, columnReviews, columnChecks, columnThreads, columnComments, columnURL:
+		default:
+			return nil, fmt.Errorf("unknown column %q: must be number, title, repo, author, time, reviews, checks, threads, comments or url", column)
+		}
+		if align != alignLeft && align != alignRight {
+			return nil, fmt.Errorf("unknown alignment %q for %s: must be left or right", align, column)
+		}
+		alignments[column] = align
+	}
+	return alignments, nil
+}
+
+// alignCell truncates s to width and pads it with spaces on the side opposite to align,
+// measuring display width so wide characters line up
+func alignCell(s string, width int, align string) string {
+	fitted := truncateString(s, width)
+	if align != alignRight {
+		return fitted
+	}
+	trimmed := strings.TrimRight(fitted, " ")
+	return strings.Repeat(" ", width-runewidth.StringWidth(trimmed)) + trimmed
+}
+
+// alignment returns the alignment of a column set with --align, falling back to its
+// default alignment
+func (pc *PRChecker) alignment(column string) string {
+	if align, ok := pc.opts.Align[column]; ok {
+		return align
+	}
+	if align, ok := defaultAlignments[column]; ok {
+		return align
+	}
+	return alignLeft
+}
+
+// columnGap returns the spaces separating table columns
+func (pc *PRChecker) columnGap() string {
+	if pc.opts.ColumnPadding > 0 {
+		return strings.Repeat(" ", pc.opts.ColumnPadding)
+	}
+	return strings.Repeat(" ", columnPadding)
+}
+
+// urlWidth returns the width of the URL column of a category's table
+func (pc *PRChecker) urlWidth(category string) int {
+	return pc.layout(category).url
+}
+
+// alignURL right-aligns a link within the URL column when requested. Links wider than
+// the column are left as they are; --truncate-url shortens them.
+func (pc *PRChecker) alignURL(link, category string) string {
+	width := pc.urlWidth(category)
+	if pc.alignment(columnURL) != alignRight || runewidth.StringWidth(link) >= width {
+		return link
+	}
+	return alignCell(link, width, alignRight)
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/mattn/go-runewidth"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAlignments(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    map[string]string
+		wantErr bool
+	}{
+		{name: "empty", value: "", want: map[string]string{}},
+		{name: "single column", value: "time=right", want: map[string]string{columnTime: alignRight}},
+		{
+			name:  "several columns",
+			value: "title=left,time=right,url=right",
+			want:  map[string]string{columnTitle: alignLeft, columnTime: alignRight, columnURL: alignRight},
+		},
+		{name: "missing alignment", value: "time", wantErr: true},
+		{name: "unknown column", value: "labels=left", wantErr: true},
+		{name: "unknown alignment", value: "time=center", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseAlignments(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestAlignCell(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		width int
+		align string
+		want  string
+	}{
+		{name: "left pads on the right", input: "2 days ago", width: 12, align: alignLeft, want: "2 days ago  "},
+		{name: "right pads on the left", input: "2 days ago", width: 12, align: alignRight, want: "  2 days ago"},
+		{name: "right with wide characters", input: "日本語", width: 10, align: alignRight, want: "    日本語"},
+		{name: "right truncates long content", input: "about 3 weeks ago", width: 10, align: alignRight, want: "about 3..."},
+		{name: "exact width", input: "now", width: 3, align: alignRight, want: "now"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := alignCell(tt.input, tt.width, tt.align)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.width, runewidth.StringWidth(got))
+		})
+	}
+}
+
+func TestColumnLayout(t *testing.T) {
+	pc := &PRChecker{width: 200}
+	widths := pc.layout(categoryCreated)
+	assert.Equal(t, "  ", pc.columnGap())
+	assert.Equal(t, 200-maxNumberLength-widths.title-widths.repo-maxUpdateLength-4*columnPadding, pc.urlWidth(categoryCreated))
+	assert.Equal(t, alignRight, pc.alignment(columnNumber))
+	assert.Equal(t, alignLeft, pc.alignment(columnURL))
+	assert.Equal(t, "URL", pc.alignURL("URL", categoryCreated))
+
+	pc.opts = Options{ColumnPadding: 4, Align: map[string]string{columnURL: alignRight}}
+	widths = pc.layout(categoryCreated)
+	assert.Equal(t, "    ", pc.columnGap())
+	assert.Equal(t, 200-maxNumberLength-widths.title-widths.repo-maxUpdateLength-16, pc.urlWidth(categoryCreated))
+
+	// Sections with an author column leave less room for the URL
+	widths = pc.layout(categoryReviewer)
+	assert.Equal(t, 200-maxNumberLength-widths.title-widths.repo-widths.author-maxUpdateLength-20, pc.urlWidth(categoryReviewer))
+	assert.Less(t, pc.urlWidth(categoryReviewer), pc.urlWidth(categoryCreated))
+
+	// Narrow terminals keep a minimal URL column even when rows overflow
+	pc.width = 60
+	assert.Equal(t, minURLLength, pc.urlWidth(categoryReviewer))
+	pc.width = 200
+
+	// A right-aligned URL ends at the table width
+	link := pc.alignURL("URL", categoryCreated)
+	assert.Equal(t, pc.urlWidth(categoryCreated), runewidth.StringWidth(link))
+	assert.Equal(t, "URL", link[len(link)-3:])
+}
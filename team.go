@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/google/go-github/v67/github"
+)
+
+// teamSlugPattern matches the slug of a GitHub team, such as "platform-reviewers"
+var teamSlugPattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// validateTeam rejects teams not in "org/team" form
+func validateTeam(team string) error {
+	org, slug, ok := strings.Cut(team, "/")
+	if !ok || !teamSlugPattern.MatchString(slug) {
+		return fmt.Errorf("invalid team %q: must be org/team", team)
+	}
+	return validateOrg(org)
+}
+
+// searchesTeam reports whether a category also searches the review requests of --team
+func (pc *PRChecker) searchesTeam(category string) bool {
+	return pc.opts.Team != "" && category == categoryReviewer
+}
+
+// buildTeamSearchQuery returns the search query for review requests sent to --team
+func (pc *PRChecker) buildTeamSearchQuery() (string, error) {
+	if err := validateTeam(pc.opts.Team); err != nil {
+		return "", err
+	}
+	return pc.buildQuery(categoryReviewer, "team-review-requested:"+pc.opts.Team)
+}
+
+// mergeSearchResults combines two searches of a category, counting PRs found by both once
+func mergeSearchResults(a, b *github.IssuesSearchResult) *github.IssuesSearchResult {
+	issues := mergeAndDedup(a.Issues, b.Issues)
+	duplicates := len(a.Issues) + len(b.Issues) - len(issues)
+	return &github.IssuesSearchResult{
+		Total:             github.Int(max(a.GetTotal()+b.GetTotal()-duplicates, len(issues))),
+		IncompleteResults: github.Bool(a.GetIncompleteResults() || b.GetIncompleteResults()),
+		Issues:            issues,
+	}
+}
+
+// mergeAndDedup concatenates lists of PRs, keeping only the first of those sharing a URL
+func mergeAndDedup(lists ...[]*github.Issue) []*github.Issue {
+	seen := make(map[string]bool)
+	var merged []*github.Issue
+	for _, list := range lists {
+		for _, issue := range list {
+			url := issue.GetHTMLURL()
+			if seen[url] {
+				continue
+			}
+			seen[url] = true
+			merged = append(merged, issue)
+		}
+	}
+	return merged
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/google/go-github/v67/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateTeam(t *testing.T) {
+	tests := []struct {
+		team    string
+		wantErr bool
+	}{
+		{team: "my-org/reviewers"},
+		{team: "my-org/platform_team.v2"},
+		{team: "my-org", wantErr: true},
+		{team: "my-org/", wantErr: true},
+		{team: "/reviewers", wantErr: true},
+		{team: "my_org/reviewers", wantErr: true},
+		{team: "my-org/reviewers/extra", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.team, func(t *testing.T) {
+			err := validateTeam(tt.team)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestBuildTeamSearchQuery(t *testing.T) {
+	pc := &PRChecker{username: "testuser", opts: Options{Team: "my-org/reviewers"}}
+	assert.True(t, pc.searchesTeam(categoryReviewer))
+	assert.False(t, pc.searchesTeam(categoryCreated))
+
+	query, err := pc.buildTeamSearchQuery()
+	assert.NoError(t, err)
+	assert.Equal(t, "is:open+is:pr+archived:false+team-review-requested:my-org/reviewers", query)
+
+	// Filters apply to the team search as to every other
+	pc.opts.Repo = "owner/repo"
+	query, err = pc.buildTeamSearchQuery()
+	assert.NoError(t, err)
+	assert.Equal(t, "is:open+is:pr+archived:false+team-review-requested:my-org/reviewers+repo:owner/repo", query)
+
+	pc.opts.Team = "my-org"
+	_, err = pc.buildTeamSearchQuery()
+	assert.Error(t, err)
+
+	pc = &PRChecker{username: "testuser"}
+	assert.False(t, pc.searchesTeam(categoryReviewer))
+}
+
+func TestMergeAndDedup(t *testing.T) {
+	first := createTestPR("first", "url1")
+	second := createTestPR("second", "url2")
+	secondAgain := createTestPR("second from team", "url2")
+	third := createTestPR("third", "url3")
+
+	tests := []struct {
+		name  string
+		lists [][]*github.Issue
+		want  []*github.Issue
+	}{
+		{
+			name: "no lists",
+		},
+		{
+			name:  "one list",
+			lists: [][]*github.Issue{{first, second}},
+			want:  []*github.Issue{first, second},
+		},
+		{
+			name:  "duplicates keep the first occurrence",
+			lists: [][]*github.Issue{{first, second}, {secondAgain, third}},
+			want:  []*github.Issue{first, second, third},
+		},
+		{
+			name:  "empty personal results",
+			lists: [][]*github.Issue{nil, {secondAgain}},
+			want:  []*github.Issue{secondAgain},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, mergeAndDedup(tt.lists...))
+		})
+	}
+}
+
+func TestMergeSearchResults(t *testing.T) {
+	personal := &github.IssuesSearchResult{Total: github.Int(2), Issues: []*github.Issue{createTestPR("a", "url1"), createTestPR("b", "url2")}}
+	team := &github.IssuesSearchResult{Total: github.Int(5), IncompleteResults: github.Bool(true), Issues: []*github.Issue{createTestPR("b", "url2"), createTestPR("c", "url3")}}
+
+	merged := mergeSearchResults(personal, team)
+	assert.Len(t, merged.Issues, 3)
+	assert.Equal(t, 6, merged.GetTotal())
+	assert.True(t, merged.GetIncompleteResults())
+}
+
+func TestSearchPullRequestsWithTeam(t *testing.T) {
+	personalPath := "search/issues?q=is:open+is:pr+archived:false+user-review-requested:testuser&sort=updated&order=desc&per_page=100"
+	teamPath := "search/issues?q=is:open+is:pr+archived:false+team-review-requested:my-org/reviewers&sort=updated&order=desc&per_page=100"
+	client := &MockGitHubClient{responses: map[string]interface{}{
+		personalPath: createTestPRList(createTestPR("personal", "url1"), createTestPR("both", "url2")),
+		teamPath:     createTestPRList(createTestPR("both", "url2"), createTestPR("team", "url3")),
+	}}
+	pc := &PRChecker{client: client, username: "testuser", opts: Options{Team: "my-org/reviewers"}}
+
+	result, _, err := pc.searchPullRequests(context.Background(), categoryReviewer)
+	assert.NoError(t, err)
+	var titles []string
+	for _, issue := range result.Issues {
+		titles = append(titles, issue.GetTitle())
+	}
+	assert.Equal(t, []string{"personal", "both", "team"}, titles)
+	assert.Equal(t, []string{personalPath, teamPath}, client.paths)
+
+	// Other categories ignore --team
+	client.paths = nil
+	_, _, err = pc.searchPullRequests(context.Background(), categoryCreated)
+	assert.NoError(t, err)
+	assert.Len(t, client.paths, 1)
+}
+
+func TestPrintQueriesWithTeam(t *testing.T) {
+	var out bytes.Buffer
+	pc := &PRChecker{username: "testuser", opts: Options{Categories: []string{categoryReviewer}, Team: "my-org/reviewers"}}
+	assert.NoError(t, pc.printQueries(&out))
+	assert.Equal(t, "requested: is:open is:pr archived:false user-review-requested:testuser\n"+
+		"requested: is:open is:pr archived:false team-review-requested:my-org/reviewers\n", out.String())
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"io"
+	"text/template"
+	"time"
+
+	"github.com/google/go-github/v67/github"
+)
+
+// templateFuncs returns the helper functions available to --template, with timeAgo
+// measuring from now
+func templateFuncs(now func() time.Time) template.FuncMap {
+	return template.FuncMap{
+		"truncate": func(maxLength int, s string) string { return truncateString(s, maxLength) },
+		"timeAgo":  func(t time.Time) string { return relativeTime(now(), t) },
+	}
+}
+
+// templateData is what a --template is executed with for each category
+type templateData struct {
+	Category string
+	PRs      []prRecord
+}
+
+// parseTemplate parses a --template string with the helper functions available
+func parseTemplate(text string, now func() time.Time) (*template.Template, error) {
+	return template.New("output").Funcs(templateFuncs(now)).Parse(text)
+}
+
+// writeTemplate executes the --template once per category with its PRs
+func (pc *PRChecker) writeTemplate(w io.Writer, categories []string, results map[string][]*github.Issue) error {
+	tmpl, err := parseTemplate(pc.opts.Template, pc.now)
+	if err != nil {
+		return err
+	}
+	for _, cat := range categories {
+		records := newPRRecords(results[cat])
+		for i := range records {
+			records[i] = pc.redact.record(records[i])
+		}
+		if err := tmpl.Execute(w, templateData{Category: cat, PRs: records}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
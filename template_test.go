@@ -0,0 +1,43 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v67/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteTemplate(t *testing.T) {
+	results := map[string][]*github.Issue{
+		categoryCreated: {createTestPR("A very long pull request title", "https://github.com/owner/repo/pull/1")},
+	}
+	pc := &PRChecker{opts: Options{Template: "{{.Category}}:{{range .PRs}} {{truncate 10 .Title}} {{.URL}}{{end}}\n"}}
+
+	var buf bytes.Buffer
+	assert.NoError(t, pc.writeTemplate(&buf, []string{categoryCreated, categoryReviewer}, results))
+	assert.Equal(t, "created: A very ... https://github.com/owner/repo/pull/1\nrequested:\n", buf.String())
+}
+
+func TestWriteTemplateTimeAgo(t *testing.T) {
+	now := time.Date(2024, 1, 2, 15, 4, 0, 0, time.UTC)
+	pr := createTestPR("Test PR", "https://github.com/owner/repo/pull/1")
+	pr.UpdatedAt = &github.Timestamp{Time: now.Add(-3 * time.Hour)}
+	pc := &PRChecker{
+		opts:  Options{Template: "{{range .PRs}}{{.Title}} {{timeAgo .UpdatedAt}}\n{{end}}"},
+		clock: func() time.Time { return now },
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, pc.writeTemplate(&buf, []string{categoryCreated}, map[string][]*github.Issue{categoryCreated: {pr}}))
+	assert.Equal(t, "Test PR about 3 hours ago\n", buf.String())
+}
+
+func TestParseTemplate(t *testing.T) {
+	_, err := parseTemplate("{{.Title | timeAgo}}", time.Now)
+	assert.NoError(t, err)
+
+	_, err = parseTemplate("{{.Title | unknown}}", time.Now)
+	assert.ErrorContains(t, err, `function "unknown" not defined`)
+}
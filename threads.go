@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/google/go-github/v67/github"
+)
+
+// Unresolved thread column
+const (
+	threadsLabel     = "Threads" // Header of the unresolved thread count column
+	maxThreadsLength = 7         // Width of the unresolved thread count column
+	threadsPerPage   = 100       // Review threads fetched per PR
+)
+
+// reviewThreadsQuery fetches the resolution state of a PR's review threads. REST has no
+// notion of resolved conversations, so this is only available over GraphQL.
+const reviewThreadsQuery = `query($owner: String!, $name: String!, $number: Int!, $first: Int!) {
+  repository(owner: $owner, name: $name) {
+    pullRequest(number: $number) {
+      reviewThreads(first: $first) {
+        nodes { isResolved }
+      }
+    }
+  }
+}`
+
+// reviewThread is a review conversation on a PR
+type reviewThread struct {
+	IsResolved bool `json:"isResolved"`
+}
+
+// reviewThreadsResponse is the data returned for reviewThreadsQuery
+type reviewThreadsResponse struct {
+	Repository struct {
+		PullRequest struct {
+			ReviewThreads struct {
+				Nodes []reviewThread `json:"nodes"`
+			} `json:"reviewThreads"`
+		} `json:"pullRequest"`
+	} `json:"repository"`
+}
+
+// fetchesThreads reports whether review threads are fetched for each PR
+func (pc *PRChecker) fetchesThreads() bool {
+	return pc.opts.Unresolved || pc.opts.HasUnresolved
+}
+
+// fetchReviewThreads returns the review threads of a PR. It is the only place that knows
+// threads come from GraphQL, so callers do not depend on the API used.
+func (pc *PRChecker) fetchReviewThreads(ctx context.Context, repo string, number int) ([]reviewThread, error) {
+	owner, name, _ := strings.Cut(repo, "/")
+	variables := map[string]interface{}{
+		"owner":  owner,
+		"name":   name,
+		"number": number,
+		"first":  threadsPerPage,
+	}
+	var response reviewThreadsResponse
+	if err := queryGraphQL(ctx, pc.client, reviewThreadsQuery, variables, &response); err != nil {
+		return nil, err
+	}
+	return response.Repository.PullRequest.ReviewThreads.Nodes, nil
+}
+
+// countUnresolved returns the number of threads that have not been resolved
+func countUnresolved(threads []reviewThread) int {
+	count := 0
+	for _, thread := range threads {
+		if !thread.IsResolved {
+			count++
+		}
+	}
+	return count
+}
+
+// keepUnresolved keeps only the PRs with at least one unresolved review thread
+func (pc *PRChecker) keepUnresolved(issues []*github.Issue) []*github.Issue {
+	var filtered []*github.Issue
+	for _, issue := range issues {
+		if details := pc.detailsFor(issue); details != nil && details.unresolved > 0 {
+			filtered = append(filtered, issue)
+		}
+	}
+	return filtered
+}
+
+// formatThreads returns the unresolved thread count shown in the table, or "-" when the
+// PR's threads were not fetched
+func (pc *PRChecker) formatThreads(issue *github.Issue) string {
+	details := pc.detailsFor(issue)
+	if details == nil {
+		return "-"
+	}
+	return strconv.Itoa(details.unresolved)
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-github/v67/github"
+	"github.com/stretchr/testify/assert"
+)
+
+// graphQLClient answers GraphQL queries with a fixed JSON document, recording the variables
+type graphQLClient struct {
+	MockGitHubClient
+	data      string
+	variables map[string]interface{}
+}
+
+func (c *graphQLClient) graphQL(ctx context.Context, query string, variables map[string]interface{}, response interface{}) error {
+	c.variables = variables
+	return json.Unmarshal([]byte(c.data), response)
+}
+
+func TestCountUnresolved(t *testing.T) {
+	tests := []struct {
+		name    string
+		threads []reviewThread
+		want    int
+	}{
+		{name: "no threads", want: 0},
+		{name: "all resolved", threads: []reviewThread{{IsResolved: true}, {IsResolved: true}}, want: 0},
+		{name: "mixed", threads: []reviewThread{{IsResolved: true}, {}, {}}, want: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, countUnresolved(tt.threads))
+		})
+	}
+}
+
+func TestFetchReviewThreads(t *testing.T) {
+	client := &graphQLClient{data: `{"repository": {"pullRequest": {"reviewThreads": {"nodes": [
+		{"isResolved": false}, {"isResolved": true}, {"isResolved": false}
+	]}}}}`}
+	pc := &PRChecker{client: client}
+
+	threads, err := pc.fetchReviewThreads(context.Background(), "owner/repo", 7)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, countUnresolved(threads))
+	assert.Equal(t, map[string]interface{}{"owner": "owner", "name": "repo", "number": 7, "first": threadsPerPage}, client.variables)
+
+	// Clients without GraphQL support report it instead of returning no threads
+	pc.client = &MockGitHubClient{}
+	_, err = pc.fetchReviewThreads(context.Background(), "owner/repo", 7)
+	assert.ErrorIs(t, err, errGraphQLUnavailable)
+}
+
+func TestKeepUnresolved(t *testing.T) {
+	open := createTestPR("Open threads", "url1")
+	resolved := createTestPR("Resolved", "url2")
+	notEnriched := createTestPR("Not enriched", "url3")
+
+	pc := &PRChecker{}
+	pc.setDetails(open, &prDetails{unresolved: 2})
+	pc.setDetails(resolved, &prDetails{})
+
+	assert.Equal(t, []*github.Issue{open}, pc.keepUnresolved([]*github.Issue{open, resolved, notEnriched}))
+	assert.Equal(t, "2", pc.formatThreads(open))
+	assert.Equal(t, "0", pc.formatThreads(resolved))
+	assert.Equal(t, "-", pc.formatThreads(notEnriched))
+}
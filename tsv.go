@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// tsvEscaper escapes the characters that would split a TSV field or line, along with
+// backslashes so the escapes stay unambiguous
+var tsvEscaper = strings.NewReplacer(`\`, `\\`, "\t", `\t`, "\n", `\n`, "\r", `\r`)
+
+// tsvField escapes a value for a TSV field, replacing other control characters with spaces
+func tsvField(value string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) && r != '\t' && r != '\n' && r != '\r' {
+			return ' '
+		}
+		return r
+	}, tsvEscaper.Replace(value))
+}
+
+// writeTSV writes one tab-separated line per PR across every category, in category
+// order, with the same columns as the CSV output and no header
+func writeTSV(w io.Writer, categories []string, records map[string][]prRecord) error {
+	for _, cat := range categories {
+		for _, record := range records[cat] {
+			fields := []string{
+				cat,
+				strconv.Itoa(record.Number),
+				record.Repo,
+				record.Title,
+				record.Author,
+				record.UpdatedAt.Format(time.RFC3339),
+				record.URL,
+			}
+			for i, field := range fields {
+				fields[i] = tsvField(field)
+			}
+			if _, err := fmt.Fprintln(w, strings.Join(fields, "\t")); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteTSV(t *testing.T) {
+	updated := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+	records := map[string][]prRecord{
+		categoryCreated: {{
+			Number:    12,
+			Title:     "Fix\ttabbed\nmultiline title",
+			URL:       "https://github.com/owner/repo/pull/12",
+			Repo:      "owner/repo",
+			Author:    "testuser",
+			UpdatedAt: updated,
+		}},
+		categoryReviewer: {{
+			Number:    3,
+			Title:     `Review C:\path "quoted", with commas`,
+			URL:       "https://github.com/other/repo/pull/3",
+			Repo:      "other/repo",
+			Author:    "octocat",
+			UpdatedAt: updated.Add(-time.Hour),
+		}},
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, writeTSV(&buf, []string{categoryCreated, categoryReviewer, categoryAssigned}, records))
+	assert.Equal(t, "created\t12\towner/repo\tFix\\ttabbed\\nmultiline title\ttestuser\t2024-01-02T15:04:05Z\thttps://github.com/owner/repo/pull/12\n"+
+		"requested\t3\tother/repo\tReview C:\\\\path \"quoted\", with commas\toctocat\t2024-01-02T14:04:05Z\thttps://github.com/other/repo/pull/3\n", buf.String())
+}
+
+func TestTSVField(t *testing.T) {
+	assert.Equal(t, "plain", tsvField("plain"))
+	assert.Equal(t, `a\tb\r\nc`, tsvField("a\tb\r\nc"))
+	assert.Equal(t, `back\\slash`, tsvField(`back\slash`))
+	assert.Equal(t, "bell ring", tsvField("bell\aring"))
+}
+
+func TestWriteTSVEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, writeTSV(&buf, []string{categoryCreated}, map[string][]prRecord{categoryCreated: {}}))
+	assert.Empty(t, buf.String())
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"time"
+
+	"github.com/google/go-github/v67/github"
+)
+
+// Default urgency weights
+const (
+	urgencyPerStaleDay      = 1.0  // Added per day since the PR was last updated
+	urgencyChangesRequested = 5.0  // Added when a reviewer's latest review requests changes
+	urgencyBlockedOnMe      = 10.0 // Added when the PR is waiting on the user's review
+)
+
+// urgencyInput captures the PR state considered when scoring urgency
+type urgencyInput struct {
+	Age              time.Duration // Time since the PR was last updated
+	ChangesRequested bool          // A reviewer's latest review requests changes
+	BlockedOnMe      bool          // The user's review is requested, so the PR waits on them
+}
+
+// urgencyScorer computes an urgency score for a PR, where higher is more urgent
+type urgencyScorer func(urgencyInput) float64
+
+// defaultUrgencyScore adds a point per stale day and fixed bonuses for requested changes
+// and for PRs blocked on the user, so a PR waiting on the user outranks ten days of staleness
+func defaultUrgencyScore(in urgencyInput) float64 {
+	score := in.Age.Hours() / 24 * urgencyPerStaleDay
+	if in.ChangesRequested {
+		score += urgencyChangesRequested
+	}
+	if in.BlockedOnMe {
+		score += urgencyBlockedOnMe
+	}
+	return score
+}
+
+// urgencyInput gathers the state used to score an issue within a category
+func (pc *PRChecker) urgencyInput(issue *github.Issue, category string, now time.Time) urgencyInput {
+	in := urgencyInput{BlockedOnMe: category == categoryReviewer}
+	if at := issueTime(issue, pc.opts.TimeField); !at.IsZero() && now.After(at) {
+		in.Age = now.Sub(at)
+	}
+	if details := pc.detailsFor(issue); details != nil {
+		in.ChangesRequested = details.decision == ReviewDecisionChangesRequested
+	}
+	return in
+}
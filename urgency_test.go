@@ -0,0 +1,71 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v67/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultUrgencyScore(t *testing.T) {
+	day := 24 * time.Hour
+
+	tests := []struct {
+		name string
+		in   urgencyInput
+		want float64
+	}{
+		{name: "fresh with no review state", in: urgencyInput{}, want: 0},
+		{name: "stale for three days", in: urgencyInput{Age: 3 * day}, want: 3},
+		{name: "changes requested", in: urgencyInput{Age: day, ChangesRequested: true}, want: 6},
+		{name: "blocked on me", in: urgencyInput{Age: 12 * time.Hour, BlockedOnMe: true}, want: 10.5},
+		{
+			name: "everything at once",
+			in:   urgencyInput{Age: 2 * day, ChangesRequested: true, BlockedOnMe: true},
+			want: 17,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.want, defaultUrgencyScore(tt.in), 1e-9)
+		})
+	}
+}
+
+func TestUrgencyInput(t *testing.T) {
+	now := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	issue := createTestPRWithNumber(1, "url1", now.Add(-48*time.Hour))
+	future := createTestPRWithNumber(2, "url2", now.Add(time.Hour))
+
+	pc := &PRChecker{}
+	pc.setDetails(issue, &prDetails{decision: ReviewDecisionChangesRequested})
+
+	assert.Equal(t, urgencyInput{Age: 48 * time.Hour, ChangesRequested: true}, pc.urgencyInput(issue, categoryCreated, now))
+	assert.Equal(t, urgencyInput{BlockedOnMe: true}, pc.urgencyInput(future, categoryReviewer, now))
+
+	// The age follows the time field shown in the table
+	future.CreatedAt = &github.Timestamp{Time: now.Add(-24 * time.Hour)}
+	pc.opts.TimeField = timeFieldCreated
+	assert.Equal(t, urgencyInput{Age: 24 * time.Hour, BlockedOnMe: true}, pc.urgencyInput(future, categoryReviewer, now))
+}
+
+func TestOrderIssuesUrgency(t *testing.T) {
+	now := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	fresh := createTestPRWithNumber(1, "url1", now.Add(-time.Hour))
+	stale := createTestPRWithNumber(2, "url2", now.AddDate(0, 0, -5))
+	blocked := createTestPRWithNumber(3, "url3", now.Add(-time.Hour))
+
+	pc := &PRChecker{opts: Options{Sort: sortUrgency}}
+	pc.setDetails(blocked, &prDetails{decision: ReviewDecisionChangesRequested})
+
+	issues := []*github.Issue{fresh, stale, blocked}
+	pc.orderIssues(categoryCreated, issues, now)
+	assert.Equal(t, []*github.Issue{blocked, stale, fresh}, issues)
+
+	// A custom scorer replaces the default weighting
+	pc.scoreUrgency = func(in urgencyInput) float64 { return -in.Age.Hours() }
+	pc.orderIssues(categoryCreated, issues, now)
+	assert.Equal(t, []*github.Issue{fresh, blocked, stale}, issues)
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/go-github/v67/github"
+)
+
+// defaultWatchInterval is the time between refreshes in watch mode
+const defaultWatchInterval = 30 * time.Second
+
+// clearScreen moves the cursor home and clears the terminal before each refresh
+const clearScreen = "\033[H\033[2J"
+
+// watcher repeats a refresh every interval until its context is cancelled
+type watcher struct {
+	interval time.Duration
+	out      io.Writer                            // Cleared before each refresh
+	errOut   io.Writer                            // Receives the errors of failed refreshes
+	after    func(time.Duration) <-chan time.Time // time.After, replaced by a fake clock in tests
+}
+
+// run clears the screen and calls refresh every interval until ctx is cancelled. A failed
+// refresh is reported and tried again at the next interval instead of ending the loop.
+func (w *watcher) run(ctx context.Context, refresh func(context.Context) error) error {
+	for {
+		fmt.Fprint(w.out, clearScreen)
+		fmt.Fprintf(w.out, "Every %s, press Ctrl-C to quit\n\n", w.interval)
+		err := refresh(ctx)
+		if ctx.Err() != nil {
+			return nil // Interrupted; the refresh error only reports the cancellation
+		}
+		if err != nil {
+			fmt.Fprintf(w.errOut, "error: %v\n", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-w.after(w.interval):
+		}
+	}
+}
+
+// changeKind describes how a PR differs from the previous refresh
+type changeKind int
+
+const (
+	changeNone    changeKind = iota // PR is unchanged since the previous refresh
+	changeNew                       // PR was not present in the previous refresh
+	changeUpdated                   // PR was present but its updated time changed
+)
+
+// Change markers prefixed to titles in watch mode
+const (
+	markerNew     = "NEW"
+	markerUpdated = "~"
+)
+
+// resultDiff describes the changes between two successive result sets for each category
+type resultDiff struct {
+	changes map[string]map[string]changeKind // category -> PR URL -> change
+	removed map[string][]*github.Issue       // category -> PRs that dropped out
+}
+
+// diffResults compares two successive result sets, keyed by category and PR URL.
+// PRs missing from curr were closed, merged or no longer match the category.
+func diffResults(prev, curr map[string][]*github.Issue) *resultDiff {
+	diff := &resultDiff{
+		changes: make(map[string]map[string]changeKind),
+		removed: make(map[string][]*github.Issue),
+	}
+
+	for category, issues := range curr {
+		previous := make(map[string]*github.Issue, len(prev[category]))
+		for _, issue := range prev[category] {
+			previous[issue.GetHTMLURL()] = issue
+		}
+
+		changes := make(map[string]changeKind, len(issues))
+		for _, issue := range issues {
+			old, ok := previous[issue.GetHTMLURL()]
+			switch {
+			case !ok:
+				changes[issue.GetHTMLURL()] = changeNew
+			case !old.GetUpdatedAt().Equal(issue.GetUpdatedAt()):
+				changes[issue.GetHTMLURL()] = changeUpdated
+			default:
+				changes[issue.GetHTMLURL()] = changeNone
+			}
+		}
+		diff.changes[category] = changes
+	}
+
+	for category, issues := range prev {
+		current := make(map[string]bool, len(curr[category]))
+		for _, issue := range curr[category] {
+			current[issue.GetHTMLURL()] = true
+		}
+		for _, issue := range issues {
+			if !current[issue.GetHTMLURL()] {
+				diff.removed[category] = append(diff.removed[category], issue)
+			}
+		}
+	}
+
+	return diff
+}
+
+// change returns how the PR changed since the previous refresh
+func (d *resultDiff) change(category string, issue *github.Issue) changeKind {
+	if d == nil {
+		return changeNone
+	}
+	return d.changes[category][issue.GetHTMLURL()]
+}
+
+// removedFrom returns the PRs that dropped out of a category since the previous refresh
+func (d *resultDiff) removedFrom(category string) []*github.Issue {
+	if d == nil {
+		return nil
+	}
+	return d.removed[category]
+}
+
+// changeMarker returns the title prefix for a change, or an empty string when unchanged
+func changeMarker(kind changeKind) string {
+	switch kind {
+	case changeNew:
+		return markerNew
+	case changeUpdated:
+		return markerUpdated
+	default:
+		return ""
+	}
+}
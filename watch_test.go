@@ -0,0 +1,142 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v67/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffResults(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	unchanged := createTestPRWithNumber(1, "url1", base)
+	updatedBefore := createTestPRWithNumber(2, "url2", base)
+	updatedAfter := createTestPRWithNumber(2, "url2", base.Add(time.Minute))
+	closed := createTestPRWithNumber(3, "url3", base)
+	added := createTestPRWithNumber(4, "url4", base)
+
+	prev := map[string][]*github.Issue{
+		categoryCreated:  {unchanged, updatedBefore, closed},
+		categoryReviewer: {},
+	}
+	curr := map[string][]*github.Issue{
+		categoryCreated:  {unchanged, updatedAfter, added},
+		categoryReviewer: {createTestPRWithNumber(1, "url1", base)},
+	}
+
+	diff := diffResults(prev, curr)
+
+	assert.Equal(t, changeNone, diff.change(categoryCreated, unchanged))
+	assert.Equal(t, changeUpdated, diff.change(categoryCreated, updatedAfter))
+	assert.Equal(t, changeNew, diff.change(categoryCreated, added))
+	assert.Equal(t, []*github.Issue{closed}, diff.removedFrom(categoryCreated))
+
+	// The same PR appearing in another category is new to that section
+	assert.Equal(t, changeNew, diff.change(categoryReviewer, unchanged))
+	assert.Empty(t, diff.removedFrom(categoryReviewer))
+}
+
+func TestDisplayRemovedSanitizesTitles(t *testing.T) {
+	closed := createTestPR("Fix\x1b[2J the\nbuild", "https://github.com/owner/repo/pull/1")
+	prev := map[string][]*github.Issue{categoryCreated: {closed}}
+	curr := map[string][]*github.Issue{categoryCreated: {}}
+
+	var out bytes.Buffer
+	pc := &PRChecker{out: &out, diff: diffResults(prev, curr)}
+	pc.displayRemoved(categoryCreated)
+
+	assert.NotContains(t, out.String(), "\x1b[2J")
+	assert.Contains(t, out.String(), "- Fix [2J the build (closed or no longer matching) https://github.com/owner/repo/pull/1\n")
+}
+
+func TestResultDiffNil(t *testing.T) {
+	var diff *resultDiff
+	assert.Equal(t, changeNone, diff.change(categoryCreated, createTestPR("PR", "url")))
+	assert.Nil(t, diff.removedFrom(categoryCreated))
+}
+
+func TestChangeMarker(t *testing.T) {
+	assert.Equal(t, "", changeMarker(changeNone))
+	assert.Equal(t, markerNew, changeMarker(changeNew))
+	assert.Equal(t, markerUpdated, changeMarker(changeUpdated))
+}
+
+// fakeClock fires every wait immediately, recording the requested durations
+type fakeClock struct {
+	waits []time.Duration
+}
+
+func (c *fakeClock) after(d time.Duration) <-chan time.Time {
+	c.waits = append(c.waits, d)
+	ch := make(chan time.Time, 1)
+	ch <- time.Time{}
+	return ch
+}
+
+func TestWatcherRun(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clock := &fakeClock{}
+	var out, errOut bytes.Buffer
+	w := &watcher{interval: 30 * time.Second, out: &out, errOut: &errOut, after: clock.after}
+
+	calls := 0
+	err := w.run(ctx, func(context.Context) error {
+		calls++
+		switch calls {
+		case 2:
+			return fmt.Errorf("temporary failure")
+		case 3:
+			cancel()
+			return ctx.Err()
+		}
+		return nil
+	})
+
+	// A failed refresh is reported without ending the loop, and cancelling stops it quietly
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, []time.Duration{30 * time.Second, 30 * time.Second}, clock.waits)
+	assert.Equal(t, 3, strings.Count(out.String(), clearScreen))
+	assert.Equal(t, "error: temporary failure\n", errOut.String())
+}
+
+func TestRunOnceRepeatedly(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := &MockGitHubClient{response: createTestPRList(createTestPR("Test PR", "url"))}
+	pc := &PRChecker{
+		client:    client,
+		username:  "testuser",
+		formatter: NewDisplayFormatter(),
+		timeout:   time.Second,
+		out:       &bytes.Buffer{},
+		opts:      Options{Watch: true, Categories: []string{categoryCreated}},
+	}
+
+	clock := &fakeClock{}
+	w := &watcher{interval: time.Minute, out: &bytes.Buffer{}, errOut: &bytes.Buffer{}, after: clock.after}
+
+	var diffs []*resultDiff
+	assert.NoError(t, w.run(ctx, func(ctx context.Context) error {
+		err := pc.runOnce(ctx)
+		diffs = append(diffs, pc.diff)
+		if len(diffs) == 3 {
+			cancel()
+		}
+		return err
+	}))
+
+	// Each refresh searches again and is compared with the one before it
+	assert.Len(t, client.requestedPaths(), 3)
+	assert.Nil(t, diffs[0])
+	assert.NotNil(t, diffs[1])
+	assert.Equal(t, changeNone, diffs[2].change(categoryCreated, pc.previous[categoryCreated][0]))
+}
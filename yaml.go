@@ -0,0 +1,26 @@
+package main
+
+import (
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// writeYAML renders the records as a YAML mapping with one list of PRs per category,
+// using the same records and keys as the JSON output
+func writeYAML(w io.Writer, categories []string, records map[string][]prRecord) error {
+	output := make(map[string][]prRecord, len(categories))
+	for _, cat := range categories {
+		output[cat] = records[cat]
+		if output[cat] == nil {
+			output[cat] = []prRecord{}
+		}
+	}
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(output); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestWriteYAML(t *testing.T) {
+	updated := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+	records := map[string][]prRecord{
+		categoryCreated: {{
+			Number:    12,
+			Title:     "Fix: handle \"quoted\" titles",
+			URL:       "https://github.com/owner/repo/pull/12",
+			Repo:      "owner/repo",
+			Author:    "testuser",
+			CreatedAt: updated.Add(-time.Hour),
+			UpdatedAt: updated,
+		}},
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, writeYAML(&buf, []string{categoryCreated, categoryReviewer}, records))
+	assert.Contains(t, buf.String(), "updated_at: 2024-01-02T15:04:05Z\n")
+	assert.Contains(t, buf.String(), "requested: []\n")
+
+	var decoded map[string][]prRecord
+	assert.NoError(t, yaml.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, map[string][]prRecord{categoryCreated: records[categoryCreated], categoryReviewer: {}}, decoded)
+}